@@ -0,0 +1,55 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// ArchiveKinds are the lists of toots tut archive can save.
+var ArchiveKinds = []string{
+	"bookmarks",
+	"favourites",
+}
+
+const archiveLimit = 40
+
+// ArchiveEntry is a toot as it was returned by the server, so no fields are
+// lost when it's archived, and the same toot decoded.
+type ArchiveEntry struct {
+	Raw    json.RawMessage
+	Status *mastodon.Status
+}
+
+// GetArchivePage returns one page of the bookmarks or favourites. The page is
+// chosen with MaxID or MinID in pg, and pg is updated with the cursors to the
+// pages before and after it.
+func (ac *AccountClient) GetArchivePage(kind string, pg *mastodon.Pagination) ([]ArchiveEntry, error) {
+	var uri string
+	switch kind {
+	case "bookmarks":
+		uri = "/api/v1/bookmarks"
+	case "favourites":
+		uri = "/api/v1/favourites"
+	default:
+		return nil, fmt.Errorf("unknown kind %s, use one of %s", kind, strings.Join(ArchiveKinds, ", "))
+	}
+	pg.Limit = archiveLimit
+	var raw []json.RawMessage
+	err := ac.doAPI(ac.Context(), http.MethodGet, uri, nil, &raw, pg)
+	if err != nil {
+		return nil, err
+	}
+	var entries []ArchiveEntry
+	for _, r := range raw {
+		var s mastodon.Status
+		if err := json.Unmarshal(r, &s); err != nil {
+			return nil, err
+		}
+		entries = append(entries, ArchiveEntry{Raw: r, Status: &s})
+	}
+	return entries, nil
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+func (ac *AccountClient) getDomainBlocks(pg *mastodon.Pagination) ([]string, error) {
+	var domains []string
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/domain_blocks", nil, &domains, pg)
+	return domains, err
+}
+
+func (ac *AccountClient) GetDomainBlocks(pg *mastodon.Pagination) ([]Item, error) {
+	var items []Item
+	domains, err := ac.getDomainBlocks(pg)
+	if err != nil {
+		return items, err
+	}
+	for _, d := range domains {
+		items = append(items, NewDomainItem(d))
+	}
+	return items, nil
+}
+
+// AccountDomain returns the domain of a, or an empty string if a is on the
+// same instance as you.
+func AccountDomain(a *mastodon.Account) string {
+	parts := strings.SplitN(a.Acct, "@", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[1]
+}
+
+func (ac *AccountClient) BlockDomain(domain string) error {
+	params := url.Values{}
+	params.Set("domain", domain)
+	return ac.doAPI(ac.Context(), http.MethodPost, "/api/v1/domain_blocks", params, nil, nil)
+}
+
+func (ac *AccountClient) UnblockDomain(domain string) error {
+	params := url.Values{}
+	params.Set("domain", domain)
+	return ac.doAPI(ac.Context(), http.MethodDelete, "/api/v1/domain_blocks", params, nil, nil)
+}
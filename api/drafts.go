@@ -0,0 +1,41 @@
+package api
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/RasmusLindroth/tut/util"
+)
+
+// draftAccount is used to keep the drafts of each account apart.
+func (ac *AccountClient) draftAccount() string {
+	host := ac.Client.Config.Server
+	if u, err := url.Parse(host); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return fmt.Sprintf("%s@%s", ac.Me.Username, host)
+}
+
+func (ac *AccountClient) GetDrafts() ([]Item, error) {
+	var items []Item
+	drafts, err := util.LoadDrafts(ac.draftAccount())
+	if err != nil {
+		return items, err
+	}
+	for _, d := range drafts {
+		items = append(items, NewDraftItem(d))
+	}
+	return items, nil
+}
+
+func (ac *AccountClient) LoadDrafts() ([]*util.Draft, error) {
+	return util.LoadDrafts(ac.draftAccount())
+}
+
+func (ac *AccountClient) SaveDraft(d *util.Draft) error {
+	return util.SaveDraft(ac.draftAccount(), d)
+}
+
+func (ac *AccountClient) DeleteDraft(d *util.Draft) error {
+	return util.DeleteDraft(ac.draftAccount(), d)
+}
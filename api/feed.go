@@ -2,6 +2,8 @@ package api
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/RasmusLindroth/go-mastodon"
@@ -105,14 +107,36 @@ func (ac *AccountClient) GetNotifications(nth []config.NotificationToHide, pg *m
 	return items, nil
 }
 
+// statusHistory has the poll of a version that go-mastodon doesn't decode.
+type statusHistory struct {
+	mastodon.StatusHistory
+	Poll *struct {
+		Options []struct {
+			Title string `json:"title"`
+		} `json:"options"`
+	} `json:"poll"`
+}
+
+// GetHistory returns the versions of an edited status, the oldest first.
 func (ac *AccountClient) GetHistory(status *mastodon.Status) ([]Item, error) {
 	var items []Item
-	statuses, err := ac.Client.GetStatusHistory(context.Background(), status.ID)
+	var versions []*statusHistory
+	uri := fmt.Sprintf("/api/v1/statuses/%s/history", status.ID)
+	err := ac.doAPI(ac.Context(), http.MethodGet, uri, nil, &versions, nil)
 	if err != nil {
 		return items, err
 	}
-	for _, s := range statuses {
-		items = append(items, NewStatusHistoryItem(s))
+	var prev *StatusHistoryItem
+	for _, v := range versions {
+		var options []string
+		if v.Poll != nil {
+			for _, o := range v.Poll.Options {
+				options = append(options, o.Title)
+			}
+		}
+		h := v.StatusHistory
+		prev = NewStatusHistoryItem(&h, options, prev)
+		items = append(items, prev)
 	}
 	return items, nil
 }
@@ -285,18 +309,6 @@ func (ac *AccountClient) GetTags(pg *mastodon.Pagination) ([]Item, error) {
 	return items, nil
 }
 
-func (ac *AccountClient) GetLists() ([]Item, error) {
-	var items []Item
-	lists, err := ac.Client.GetLists(context.Background())
-	if err != nil {
-		return items, err
-	}
-	for _, l := range lists {
-		items = append(items, NewListsItem(l))
-	}
-	return items, nil
-}
-
 func (ac *AccountClient) GetListStatuses(pg *mastodon.Pagination, id mastodon.ID) ([]Item, error) {
 	var items []Item
 	statuses, err := ac.Client.GetTimelineList(context.Background(), id, pg)
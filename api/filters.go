@@ -0,0 +1,160 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// FilterContexts are the places a filter can be applied to.
+var FilterContexts = []string{
+	"home",
+	"notifications",
+	"public",
+	"thread",
+	"account",
+}
+
+type Filter struct {
+	ID           mastodon.ID     `json:"id"`
+	Title        string          `json:"title"`
+	Context      []string        `json:"context"`
+	ExpiresAt    *time.Time      `json:"expires_at"`
+	FilterAction string          `json:"filter_action"`
+	Keywords     []FilterKeyword `json:"keywords"`
+	Statuses     []FilterStatus  `json:"statuses"`
+}
+
+type FilterKeyword struct {
+	ID        mastodon.ID `json:"id"`
+	Keyword   string      `json:"keyword"`
+	WholeWord bool        `json:"whole_word"`
+}
+
+type FilterStatus struct {
+	ID       mastodon.ID `json:"id"`
+	StatusID mastodon.ID `json:"status_id"`
+}
+
+// FilterData is what a FilterItem holds. Status is set when the filter is
+// listed to add a status to it.
+type FilterData struct {
+	Filter *Filter
+	Status *mastodon.Status
+}
+
+// FilterParams is used to create or update a filter. Keywords without an ID
+// are created and RemovedKeywords are deleted. ExpiresIn zero means that the
+// filter never expires.
+type FilterParams struct {
+	Title           string
+	Context         []string
+	FilterAction    string
+	ExpiresIn       time.Duration
+	Keywords        []FilterKeyword
+	RemovedKeywords []mastodon.ID
+}
+
+func (p *FilterParams) values() url.Values {
+	params := url.Values{}
+	params.Set("title", p.Title)
+	for _, c := range p.Context {
+		params.Add("context[]", c)
+	}
+	params.Set("filter_action", p.FilterAction)
+	if p.ExpiresIn > 0 {
+		params.Set("expires_in", fmt.Sprint(int64(p.ExpiresIn.Seconds())))
+	} else {
+		params.Set("expires_in", "")
+	}
+	i := 0
+	for _, k := range p.Keywords {
+		key := fmt.Sprintf("keywords_attributes[%d]", i)
+		if k.ID != "" {
+			params.Set(key+"[id]", string(k.ID))
+		}
+		params.Set(key+"[keyword]", k.Keyword)
+		params.Set(key+"[whole_word]", fmt.Sprint(k.WholeWord))
+		i++
+	}
+	for _, id := range p.RemovedKeywords {
+		key := fmt.Sprintf("keywords_attributes[%d]", i)
+		params.Set(key+"[id]", string(id))
+		params.Set(key+"[_destroy]", "true")
+		i++
+	}
+	return params
+}
+
+func (ac *AccountClient) getFilters() ([]*Filter, error) {
+	var filters []*Filter
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v2/filters", nil, &filters, nil)
+	return filters, err
+}
+
+func (ac *AccountClient) GetFilters() ([]Item, error) {
+	var items []Item
+	filters, err := ac.getFilters()
+	if err != nil {
+		return items, err
+	}
+	for _, f := range filters {
+		items = append(items, NewFilterItem(&FilterData{Filter: f}))
+	}
+	return items, nil
+}
+
+// GetFiltersStatus lists the filters so the status can be added to one of
+// them.
+func (ac *AccountClient) GetFiltersStatus(status *mastodon.Status) ([]Item, error) {
+	var items []Item
+	filters, err := ac.getFilters()
+	if err != nil {
+		return items, err
+	}
+	for _, f := range filters {
+		items = append(items, NewFilterItem(&FilterData{Filter: f, Status: status}))
+	}
+	return items, nil
+}
+
+func (ac *AccountClient) CreateFilter(p *FilterParams) (*Filter, error) {
+	var f Filter
+	err := ac.doAPI(ac.Context(), http.MethodPost, "/api/v2/filters", p.values(), &f, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (ac *AccountClient) UpdateFilter(id mastodon.ID, p *FilterParams) (*Filter, error) {
+	var f Filter
+	err := ac.doAPI(ac.Context(), http.MethodPut, fmt.Sprintf("/api/v2/filters/%s", id), p.values(), &f, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func (ac *AccountClient) DeleteFilter(f *Filter) error {
+	return ac.doAPI(ac.Context(), http.MethodDelete, fmt.Sprintf("/api/v2/filters/%s", f.ID), nil, nil, nil)
+}
+
+// AddStatusToFilter filters the status, or the status it boosts, with f.
+func (ac *AccountClient) AddStatusToFilter(f *Filter, status *mastodon.Status) (*FilterStatus, error) {
+	if status.Reblog != nil {
+		status = status.Reblog
+	}
+	params := url.Values{}
+	params.Set("status_id", string(status.ID))
+	var fs FilterStatus
+	err := ac.doAPI(ac.Context(), http.MethodPost, fmt.Sprintf("/api/v2/filters/%s/statuses", f.ID), params, &fs, nil)
+	if err != nil {
+		return nil, err
+	}
+	f.Statuses = append(f.Statuses, fs)
+	return &fs, nil
+}
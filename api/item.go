@@ -112,7 +112,22 @@ type StatusItem struct {
 	showSpoiler bool
 	forceView   bool
 	filtered    filtered
+	client      *config.Filter
 	pinned      bool
+	thread      *ThreadPosition
+}
+
+// ThreadPosition is where a status is in the reply tree of a thread.
+type ThreadPosition struct {
+	// Prefix holds the branch glyphs that are drawn before the status.
+	Prefix      string
+	Parent      mastodon.ID
+	NextSibling mastodon.ID
+	Replies     int
+	// Descendants is the number of statuses in the subtree of the status,
+	// they follow the status in the feed.
+	Descendants int
+	Collapsed   bool
 }
 
 func (s *StatusItem) ID() uint {
@@ -139,12 +154,32 @@ func (s *StatusItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Tag, int
 	return getUrlsStatus(s.item)
 }
 
+// SetClientFilter sets the filter from the config that matches the status,
+// or nil if none does.
+func (s *StatusItem) SetClientFilter(f *config.Filter) {
+	s.client = f
+}
+
+// ThreadPosition returns the position of the status in a thread, or nil if
+// the item isn't in a thread feed.
+func (s *StatusItem) ThreadPosition() *ThreadPosition {
+	return s.thread
+}
+
+func (s *StatusItem) SetThreadPosition(tp *ThreadPosition) {
+	s.thread = tp
+}
+
 func (s *StatusItem) Filtered(tl config.FeedType) (bool, string, string, bool) {
-	if !s.filtered.InUse || s.forceView {
+	if (!s.filtered.InUse && s.client == nil) || s.forceView {
 		return false, "", "", true
 	}
 	words := []string{}
 	t := ""
+	if s.client != nil {
+		words = append(words, s.client.Name)
+		t = s.client.Action
+	}
 	for _, f := range s.filtered.Filters {
 		used := false
 		for _, w := range f.Where {
@@ -170,6 +205,8 @@ func (s *StatusItem) Filtered(tl config.FeedType) (bool, string, string, bool) {
 					config.TimelineFederated,
 					config.TimelineLocal,
 					config.Tag,
+					config.TrendingStatuses,
+					config.Link,
 				}
 				if slices.Contains(where, tl) {
 					used = true
@@ -201,18 +238,71 @@ func (s *StatusItem) Refetch(ac *AccountClient) bool {
 		return false
 	}
 	nsi := NewStatusItemID(ns, s.pinned, s.id)
+	nsi.(*StatusItem).client = s.client
+	nsi.(*StatusItem).thread = s.thread
 	*s = *nsi.(*StatusItem)
 	return true
 }
 
-func NewStatusHistoryItem(item *mastodon.StatusHistory) (sitem Item) {
-	return &StatusHistoryItem{id: newID(), item: item, showSpoiler: false}
+// UpdateStatus replaces the status, or the status it boosts, if it has the
+// same ID as status. The item keeps its ID, CW and filter state.
+func (s *StatusItem) UpdateStatus(status *mastodon.Status) bool {
+	if s.item == nil || status == nil {
+		return false
+	}
+	old := s.item
+	if old.ID != status.ID {
+		if old.Reblog == nil || old.Reblog.ID != status.ID {
+			return false
+		}
+		old = old.Reblog
+	}
+	// The stream doesn't know who is reading, so keep the relation to the
+	// status that we already have.
+	ns := *status
+	ns.Favourited = old.Favourited
+	ns.Reblogged = old.Reblogged
+	ns.Bookmarked = old.Bookmarked
+	ns.Muted = old.Muted
+	ns.Pinned = old.Pinned
+	ns.Filtered = old.Filtered
+	*old = ns
+	return true
+}
+
+func NewStatusHistoryItem(item *mastodon.StatusHistory, pollOptions []string, prev *StatusHistoryItem) *StatusHistoryItem {
+	return &StatusHistoryItem{id: newID(), item: item, showSpoiler: false, pollOptions: pollOptions, prev: prev}
 }
 
 type StatusHistoryItem struct {
 	id          uint
 	item        *mastodon.StatusHistory
 	showSpoiler bool
+	pollOptions []string
+	prev        *StatusHistoryItem
+	showDiff    bool
+}
+
+// PollOptions returns the options of the poll in this version, go-mastodon
+// doesn't decode them.
+func (s *StatusHistoryItem) PollOptions() []string {
+	return s.pollOptions
+}
+
+// Previous returns the version before this one, or nil if this is the
+// original toot.
+func (s *StatusHistoryItem) Previous() *StatusHistoryItem {
+	return s.prev
+}
+
+// ShowDiff reports if the changes from the previous version are shown
+// instead of the full version.
+func (s *StatusHistoryItem) ShowDiff() bool {
+	return s.showDiff && s.prev != nil
+}
+
+func (s *StatusHistoryItem) SetShowDiff(show bool) {
+	s.showDiff = show
 }
 
 func (s *StatusHistoryItem) ID() uint {
@@ -387,11 +477,20 @@ func (n *NotificationItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Ta
 	}
 }
 
-func (n *NotificationItem) Filtered(config.FeedType) (bool, string, string, bool) {
-	return false, "", "", true
+// Filtered uses the filters of the status in the notification, so a filter
+// can hide notifications about it.
+func (n *NotificationItem) Filtered(tl config.FeedType) (bool, string, string, bool) {
+	if n.status == nil {
+		return false, "", "", true
+	}
+	return n.status.Filtered(tl)
 }
 
-func (n *NotificationItem) ForceViewFilter() {}
+func (n *NotificationItem) ForceViewFilter() {
+	if n.status != nil {
+		n.status.ForceViewFilter()
+	}
+}
 
 func (n *NotificationItem) Pinned() bool {
 	return false
@@ -401,13 +500,13 @@ func (n *NotificationItem) Refetch(ac *AccountClient) bool {
 	return false
 }
 
-func NewListsItem(item *mastodon.List) Item {
+func NewListsItem(item *List) Item {
 	return &ListItem{id: newID(), item: item, showSpoiler: true}
 }
 
 type ListItem struct {
 	id          uint
-	item        *mastodon.List
+	item        *List
 	showSpoiler bool
 }
 
@@ -494,3 +593,241 @@ func (t *TagItem) Pinned() bool {
 func (t *TagItem) Refetch(ac *AccountClient) bool {
 	return false
 }
+
+func NewScheduledItem(item *ScheduledStatus) Item {
+	return &ScheduledItem{id: newID(), item: item, showSpoiler: false}
+}
+
+type ScheduledItem struct {
+	id          uint
+	item        *ScheduledStatus
+	showSpoiler bool
+}
+
+func (s *ScheduledItem) ID() uint {
+	return s.id
+}
+
+func (s *ScheduledItem) Type() MastodonType {
+	return ScheduledType
+}
+
+func (s *ScheduledItem) ToggleCW() {
+	s.showSpoiler = !s.showSpoiler
+}
+
+func (s *ScheduledItem) ShowCW() bool {
+	return s.showSpoiler
+}
+
+func (s *ScheduledItem) Raw() interface{} {
+	return s.item
+}
+
+func (s *ScheduledItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Tag, int) {
+	return nil, nil, nil, 0
+}
+
+func (s *ScheduledItem) Filtered(config.FeedType) (bool, string, string, bool) {
+	return false, "", "", true
+}
+
+func (s *ScheduledItem) ForceViewFilter() {}
+
+func (s *ScheduledItem) Pinned() bool {
+	return false
+}
+
+func (s *ScheduledItem) Refetch(ac *AccountClient) bool {
+	return false
+}
+
+func NewDraftItem(item *util.Draft) Item {
+	return &DraftItem{id: newID(), item: item, showSpoiler: false}
+}
+
+type DraftItem struct {
+	id          uint
+	item        *util.Draft
+	showSpoiler bool
+}
+
+func (d *DraftItem) ID() uint {
+	return d.id
+}
+
+func (d *DraftItem) Type() MastodonType {
+	return DraftType
+}
+
+func (d *DraftItem) ToggleCW() {
+	d.showSpoiler = !d.showSpoiler
+}
+
+func (d *DraftItem) ShowCW() bool {
+	return d.showSpoiler
+}
+
+func (d *DraftItem) Raw() interface{} {
+	return d.item
+}
+
+func (d *DraftItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Tag, int) {
+	return nil, nil, nil, 0
+}
+
+func (d *DraftItem) Filtered(config.FeedType) (bool, string, string, bool) {
+	return false, "", "", true
+}
+
+func (d *DraftItem) ForceViewFilter() {}
+
+func (d *DraftItem) Pinned() bool {
+	return false
+}
+
+func (d *DraftItem) Refetch(ac *AccountClient) bool {
+	return false
+}
+
+func NewFilterItem(item *FilterData) Item {
+	return &FilterItem{id: newID(), item: item, showSpoiler: false}
+}
+
+type FilterItem struct {
+	id          uint
+	item        *FilterData
+	showSpoiler bool
+}
+
+func (f *FilterItem) ID() uint {
+	return f.id
+}
+
+func (f *FilterItem) Type() MastodonType {
+	return FilterType
+}
+
+func (f *FilterItem) ToggleCW() {
+	f.showSpoiler = !f.showSpoiler
+}
+
+func (f *FilterItem) ShowCW() bool {
+	return f.showSpoiler
+}
+
+func (f *FilterItem) Raw() interface{} {
+	return f.item
+}
+
+func (f *FilterItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Tag, int) {
+	return nil, nil, nil, 0
+}
+
+func (f *FilterItem) Filtered(config.FeedType) (bool, string, string, bool) {
+	return false, "", "", true
+}
+
+func (f *FilterItem) ForceViewFilter() {}
+
+func (f *FilterItem) Pinned() bool {
+	return false
+}
+
+func (f *FilterItem) Refetch(ac *AccountClient) bool {
+	return false
+}
+
+func NewDomainItem(item string) Item {
+	return &DomainItem{id: newID(), item: item, showSpoiler: false}
+}
+
+type DomainItem struct {
+	id          uint
+	item        string
+	showSpoiler bool
+}
+
+func (d *DomainItem) ID() uint {
+	return d.id
+}
+
+func (d *DomainItem) Type() MastodonType {
+	return DomainType
+}
+
+func (d *DomainItem) ToggleCW() {
+}
+
+func (d *DomainItem) ShowCW() bool {
+	return false
+}
+
+func (d *DomainItem) Raw() interface{} {
+	return d.item
+}
+
+func (d *DomainItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Tag, int) {
+	return nil, nil, nil, 0
+}
+
+func (d *DomainItem) Filtered(config.FeedType) (bool, string, string, bool) {
+	return false, "", "", true
+}
+
+func (d *DomainItem) ForceViewFilter() {}
+
+func (d *DomainItem) Pinned() bool {
+	return false
+}
+
+func (d *DomainItem) Refetch(ac *AccountClient) bool {
+	return false
+}
+
+func NewLinkItem(item *TrendingLink) Item {
+	return &LinkItem{id: newID(), item: item, showSpoiler: false}
+}
+
+type LinkItem struct {
+	id          uint
+	item        *TrendingLink
+	showSpoiler bool
+}
+
+func (l *LinkItem) ID() uint {
+	return l.id
+}
+
+func (l *LinkItem) Type() MastodonType {
+	return LinkType
+}
+
+func (l *LinkItem) ToggleCW() {
+}
+
+func (l *LinkItem) ShowCW() bool {
+	return false
+}
+
+func (l *LinkItem) Raw() interface{} {
+	return l.item
+}
+
+func (l *LinkItem) URLs() ([]util.URL, []mastodon.Mention, []mastodon.Tag, int) {
+	return nil, nil, nil, 0
+}
+
+func (l *LinkItem) Filtered(config.FeedType) (bool, string, string, bool) {
+	return false, "", "", true
+}
+
+func (l *LinkItem) ForceViewFilter() {}
+
+func (l *LinkItem) Pinned() bool {
+	return false
+}
+
+func (l *LinkItem) Refetch(ac *AccountClient) bool {
+	return false
+}
@@ -0,0 +1,69 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// ListRepliesPolicies are the values replies_policy can have, in the order
+// they are cycled through.
+var ListRepliesPolicies = []string{
+	"list",
+	"followed",
+	"none",
+}
+
+// List adds the fields go-mastodon doesn't know about to mastodon.List.
+type List struct {
+	mastodon.List
+	RepliesPolicy string `json:"replies_policy"`
+	Exclusive     bool   `json:"exclusive"`
+}
+
+func (ac *AccountClient) GetLists() ([]Item, error) {
+	var items []Item
+	var lists []*List
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/lists", nil, &lists, nil)
+	if err != nil {
+		return items, err
+	}
+	for _, l := range lists {
+		items = append(items, NewListsItem(l))
+	}
+	return items, nil
+}
+
+func listParams(title string, repliesPolicy string, exclusive bool) url.Values {
+	params := url.Values{}
+	params.Set("title", title)
+	if repliesPolicy != "" {
+		params.Set("replies_policy", repliesPolicy)
+	}
+	params.Set("exclusive", fmt.Sprint(exclusive))
+	return params
+}
+
+func (ac *AccountClient) CreateList(title string) (*List, error) {
+	var l List
+	err := ac.doAPI(ac.Context(), http.MethodPost, "/api/v1/lists", listParams(title, "", false), &l, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (ac *AccountClient) UpdateList(id mastodon.ID, title string, repliesPolicy string, exclusive bool) (*List, error) {
+	var l List
+	err := ac.doAPI(ac.Context(), http.MethodPut, fmt.Sprintf("/api/v1/lists/%s", id), listParams(title, repliesPolicy, exclusive), &l, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (ac *AccountClient) DeleteList(l *List) error {
+	return ac.doAPI(ac.Context(), http.MethodDelete, fmt.Sprintf("/api/v1/lists/%s", l.ID), nil, nil, nil)
+}
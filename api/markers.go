@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// Marker is how far you have read in the home or notifications timeline. It's
+// saved on the instance so it's shared with other apps.
+type Marker struct {
+	LastReadID mastodon.ID `json:"last_read_id"`
+	Version    int         `json:"version"`
+}
+
+// GetMarker returns the marker for timeline, which is home or notifications.
+// It returns nil if there isn't one yet.
+func (ac *AccountClient) GetMarker(timeline string) (*Marker, error) {
+	params := url.Values{}
+	params.Add("timeline[]", timeline)
+	markers := make(map[string]*Marker)
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/markers", params, &markers, nil)
+	if err != nil {
+		return nil, err
+	}
+	return markers[timeline], nil
+}
+
+// SetMarker saves that you have read timeline up to lastReadID.
+func (ac *AccountClient) SetMarker(timeline string, lastReadID mastodon.ID) error {
+	params := url.Values{}
+	params.Set(timeline+"[last_read_id]", string(lastReadID))
+	return ac.doAPI(ac.Context(), http.MethodPost, "/api/v1/markers", params, nil, nil)
+}
@@ -0,0 +1,92 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// rateLimit holds what the server said about the rate limit in the headers of
+// the last response.
+type rateLimit struct {
+	mux       sync.Mutex
+	known     bool
+	remaining int
+	reset     time.Time
+}
+
+func (rl *rateLimit) update(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := time.Parse(time.RFC3339, h.Get("X-RateLimit-Reset"))
+	if err != nil {
+		return
+	}
+	rl.mux.Lock()
+	rl.known = true
+	rl.remaining = remaining
+	rl.reset = reset
+	rl.mux.Unlock()
+}
+
+// untilReset returns the time left until the rate limit resets, or zero if
+// it's unknown.
+func (rl *rateLimit) untilReset() time.Duration {
+	rl.mux.Lock()
+	defer rl.mux.Unlock()
+	if !rl.known {
+		return 0
+	}
+	d := time.Until(rl.reset)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// RateLimitWait returns how long to wait before the next request if fewer
+// than min requests are left before the rate limit resets, otherwise zero.
+func (ac *AccountClient) RateLimitWait(min int) time.Duration {
+	ac.rateLimit.mux.Lock()
+	remaining := ac.rateLimit.remaining
+	known := ac.rateLimit.known
+	ac.rateLimit.mux.Unlock()
+	if !known || remaining >= min {
+		return 0
+	}
+	return ac.rateLimit.untilReset()
+}
+
+// How long a request waits in total for the rate limit before it fails. The
+// UI waits for many of the requests, so it would freeze if they waited longer.
+const interactiveRetryWait = 5 * time.Second
+
+// How long a request from SetBatch waits in total for the rate limit.
+const batchRetryWait = time.Hour
+
+// SetBatch is used by the commands that run outside of the UI, like tut
+// archive. The requests wait until the rate limit resets instead of failing,
+// and they stop when ctx is cancelled.
+func (ac *AccountClient) SetBatch(ctx context.Context) {
+	ac.ctx = ctx
+	ac.retryWait = batchRetryWait
+}
+
+// Context is cancelled when the requests should stop, see SetBatch.
+func (ac *AccountClient) Context() context.Context {
+	if ac.ctx == nil {
+		return context.Background()
+	}
+	return ac.ctx
+}
+
+func (ac *AccountClient) maxRetryWait() time.Duration {
+	if ac.retryWait == 0 {
+		return interactiveRetryWait
+	}
+	return ac.retryWait
+}
@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// ReportCategories are the categories a report can have. Reports in the
+// violation category have to include the rules that are broken.
+var ReportCategories = []string{
+	"spam",
+	"legal",
+	"violation",
+	"other",
+}
+
+// ReportParams is used to report an account and some of its statuses to the
+// moderators. Forward sends the report to the account's instance as well.
+type ReportParams struct {
+	Account   *mastodon.Account
+	StatusIDs []mastodon.ID
+	Comment   string
+	Forward   bool
+	Category  string
+	RuleIDs   []string
+}
+
+// GetRules returns the rules of your instance.
+func (ac *AccountClient) GetRules() ([]mastodon.Rule, error) {
+	var rules []mastodon.Rule
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/instance/rules", nil, &rules, nil)
+	return rules, err
+}
+
+// GetReportStatuses returns the latest statuses of an account so they can
+// be added to a report.
+func (ac *AccountClient) GetReportStatuses(id mastodon.ID) ([]*mastodon.Status, error) {
+	return ac.Client.GetAccountStatuses(context.Background(), id, &mastodon.Pagination{Limit: 40})
+}
+
+func (ac *AccountClient) Report(p *ReportParams) error {
+	params := url.Values{}
+	params.Set("account_id", string(p.Account.ID))
+	for _, id := range p.StatusIDs {
+		params.Add("status_ids[]", string(id))
+	}
+	params.Set("comment", p.Comment)
+	params.Set("forward", fmt.Sprint(p.Forward))
+	params.Set("category", p.Category)
+	if p.Category == "violation" {
+		for _, id := range p.RuleIDs {
+			params.Add("rule_ids[]", id)
+		}
+	}
+	return ac.doAPI(ac.Context(), http.MethodPost, "/api/v1/reports", params, nil, nil)
+}
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/tomnomnom/linkheader"
+)
+
+// doAPI is used for the endpoints that go-mastodon doesn't support yet. It
+// works like the unexported doAPI in go-mastodon.
+func (ac *AccountClient) doAPI(ctx context.Context, method string, uri string, params url.Values, res interface{}, pg *mastodon.Pagination) error {
+	u, err := url.Parse(ac.Client.Config.Server)
+	if err != nil {
+		return err
+	}
+	u.Path = path.Join(u.Path, uri)
+	if params == nil {
+		params = url.Values{}
+	}
+	var body io.Reader
+	if method == http.MethodGet {
+		if pg != nil {
+			setPagination(params, pg)
+		}
+		u.RawQuery = params.Encode()
+	} else {
+		body = strings.NewReader(params.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+ac.Client.Config.AccessToken)
+	if method != http.MethodGet {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if ac.Client.UserAgent != "" {
+		req.Header.Set("User-Agent", ac.Client.UserAgent)
+	}
+
+	var resp *http.Response
+	backoff := time.Second
+	var waited time.Duration
+	for {
+		if body != nil {
+			req.Body = io.NopCloser(strings.NewReader(params.Encode()))
+		}
+		resp, err = ac.Client.Do(req)
+		if err != nil {
+			return err
+		}
+		ac.rateLimit.update(resp.Header)
+		if resp.StatusCode != http.StatusTooManyRequests {
+			break
+		}
+		wait := backoff
+		if d := ac.rateLimit.untilReset(); d > wait {
+			wait = d
+		}
+		if waited+wait > ac.maxRetryWait() {
+			break
+		}
+		resp.Body.Close()
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		waited += wait
+		backoff = time.Duration(1.5 * float64(backoff))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiError(resp)
+	}
+	if pg != nil {
+		*pg = mastodon.Pagination{}
+		if lh := resp.Header.Get("Link"); lh != "" {
+			parsePagination(lh, pg)
+		}
+	}
+	if res == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+func setPagination(params url.Values, pg *mastodon.Pagination) {
+	if pg.MaxID != "" {
+		params.Set("max_id", string(pg.MaxID))
+	}
+	if pg.SinceID != "" {
+		params.Set("since_id", string(pg.SinceID))
+	}
+	if pg.MinID != "" {
+		params.Set("min_id", string(pg.MinID))
+	}
+	if pg.Limit > 0 {
+		params.Set("limit", fmt.Sprint(pg.Limit))
+	}
+}
+
+func parsePagination(lh string, pg *mastodon.Pagination) {
+	for _, link := range linkheader.Parse(lh) {
+		u, err := url.Parse(link.URL)
+		if err != nil {
+			continue
+		}
+		switch link.Rel {
+		case "next":
+			pg.MaxID = mastodon.ID(u.Query().Get("max_id"))
+		case "prev":
+			pg.SinceID = mastodon.ID(u.Query().Get("since_id"))
+			pg.MinID = mastodon.ID(u.Query().Get("min_id"))
+		}
+	}
+}
+
+func apiError(resp *http.Response) error {
+	msg := fmt.Sprintf("bad request: %s", resp.Status)
+	var e struct {
+		Error string `json:"error"`
+	}
+	json.NewDecoder(resp.Body).Decode(&e)
+	if e.Error != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Error)
+	}
+	return errors.New(msg)
+}
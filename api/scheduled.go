@@ -0,0 +1,143 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+type ScheduledStatus struct {
+	ID               mastodon.ID           `json:"id"`
+	ScheduledAt      time.Time             `json:"scheduled_at"`
+	Params           ScheduledParams       `json:"params"`
+	MediaAttachments []mastodon.Attachment `json:"media_attachments"`
+}
+
+// ScheduledParams holds the params the status was scheduled with. Mastodon
+// stores them as they were sent, so booleans and numbers can be strings.
+type ScheduledParams struct {
+	Text        string         `json:"text"`
+	Poll        *ScheduledPoll `json:"poll"`
+	MediaIDs    []mastodon.ID  `json:"media_ids"`
+	Sensitive   interface{}    `json:"sensitive"`
+	SpoilerText string         `json:"spoiler_text"`
+	Visibility  string         `json:"visibility"`
+	InReplyToID mastodon.ID    `json:"in_reply_to_id"`
+	Language    string         `json:"language"`
+}
+
+type ScheduledPoll struct {
+	Options    []string    `json:"options"`
+	ExpiresIn  interface{} `json:"expires_in"`
+	Multiple   interface{} `json:"multiple"`
+	HideTotals interface{} `json:"hide_totals"`
+}
+
+func (p ScheduledParams) IsSensitive() bool {
+	return looseBool(p.Sensitive)
+}
+
+func (p ScheduledPoll) IsMultiple() bool {
+	return looseBool(p.Multiple)
+}
+
+func (p ScheduledPoll) ExpiresInSeconds() int64 {
+	switch v := p.ExpiresIn.(type) {
+	case float64:
+		return int64(v)
+	case string:
+		i, _ := strconv.ParseInt(v, 10, 64)
+		return i
+	}
+	return 0
+}
+
+func looseBool(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		r, _ := strconv.ParseBool(b)
+		return r
+	}
+	return false
+}
+
+// ScheduleStatus works like Client.PostStatus, but also sends scheduled_at
+// which go-mastodon leaves out.
+func (ac *AccountClient) ScheduleStatus(toot *mastodon.Toot) (*ScheduledStatus, error) {
+	if toot.ScheduledAt == nil {
+		return nil, fmt.Errorf("no time to schedule the toot at")
+	}
+	params := url.Values{}
+	params.Set("status", toot.Status)
+	params.Set("scheduled_at", toot.ScheduledAt.UTC().Format(time.RFC3339))
+	if toot.InReplyToID != "" {
+		params.Set("in_reply_to_id", string(toot.InReplyToID))
+	}
+	for _, media := range toot.MediaIDs {
+		params.Add("media_ids[]", string(media))
+	}
+	if toot.Poll != nil && toot.Poll.Options != nil && toot.MediaIDs == nil {
+		for _, opt := range toot.Poll.Options {
+			params.Add("poll[options][]", opt)
+		}
+		params.Set("poll[expires_in]", fmt.Sprint(toot.Poll.ExpiresInSeconds))
+		if toot.Poll.Multiple {
+			params.Set("poll[multiple]", "true")
+		}
+		if toot.Poll.HideTotals {
+			params.Set("poll[hide_totals]", "true")
+		}
+	}
+	if toot.Visibility != "" {
+		params.Set("visibility", toot.Visibility)
+	}
+	if toot.Language != "" {
+		params.Set("language", toot.Language)
+	}
+	if toot.Sensitive {
+		params.Set("sensitive", "true")
+	}
+	if toot.SpoilerText != "" {
+		params.Set("spoiler_text", toot.SpoilerText)
+	}
+	var s ScheduledStatus
+	err := ac.doAPI(ac.Context(), http.MethodPost, "/api/v1/statuses", params, &s, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func (ac *AccountClient) GetScheduledStatuses(pg *mastodon.Pagination) ([]Item, error) {
+	var items []Item
+	var statuses []*ScheduledStatus
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/scheduled_statuses", nil, &statuses, pg)
+	if err != nil {
+		return items, err
+	}
+	for _, s := range statuses {
+		items = append(items, NewScheduledItem(s))
+	}
+	return items, nil
+}
+
+func (ac *AccountClient) RescheduleStatus(s *ScheduledStatus, t time.Time) (*ScheduledStatus, error) {
+	params := url.Values{}
+	params.Set("scheduled_at", t.UTC().Format(time.RFC3339))
+	var ns ScheduledStatus
+	err := ac.doAPI(ac.Context(), http.MethodPut, fmt.Sprintf("/api/v1/scheduled_statuses/%s", s.ID), params, &ns, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &ns, nil
+}
+
+func (ac *AccountClient) CancelScheduledStatus(s *ScheduledStatus) error {
+	return ac.doAPI(ac.Context(), http.MethodDelete, fmt.Sprintf("/api/v1/scheduled_statuses/%s", s.ID), nil, nil, nil)
+}
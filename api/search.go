@@ -0,0 +1,94 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+type SearchType string
+
+const (
+	SearchStatuses SearchType = "statuses"
+	SearchAccounts SearchType = "accounts"
+	SearchHashtags SearchType = "hashtags"
+)
+
+const searchLimit = 20
+
+var searchHasValues = []string{"media", "poll", "link", "image", "video", "audio", "embed"}
+
+// ParseSearch checks the from:, has: and before: operators in a query. If the
+// query uses any of them only statuses can match.
+func ParseSearch(query string) (statusesOnly bool, err error) {
+	for _, p := range strings.Fields(query) {
+		p = strings.TrimPrefix(p, "-")
+		key, value, found := strings.Cut(p, ":")
+		if !found {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "from":
+			if value == "" {
+				return false, fmt.Errorf("from: needs an account")
+			}
+			statusesOnly = true
+		case "has":
+			ok := false
+			for _, v := range searchHasValues {
+				if strings.ToLower(value) == v {
+					ok = true
+					break
+				}
+			}
+			if !ok {
+				return false, fmt.Errorf("has: must be one of %s", strings.Join(searchHasValues, ", "))
+			}
+			statusesOnly = true
+		case "before":
+			if _, err := time.Parse("2006-01-02", value); err != nil {
+				return false, fmt.Errorf("before: must be a date like 2006-01-02")
+			}
+			statusesOnly = true
+		}
+	}
+	return statusesOnly, nil
+}
+
+func (ac *AccountClient) GetSearch(search string, st SearchType, offset int) ([]Item, error) {
+	var items []Item
+	params := url.Values{}
+	params.Set("q", search)
+	params.Set("type", string(st))
+	params.Set("resolve", "true")
+	params.Set("limit", fmt.Sprint(searchLimit))
+	params.Set("offset", fmt.Sprint(offset))
+	var res mastodon.Results
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v2/search", params, &res, nil)
+	if err != nil {
+		return items, err
+	}
+	switch st {
+	case SearchStatuses:
+		for _, s := range res.Statuses {
+			items = append(items, NewStatusItem(s, false))
+		}
+	case SearchAccounts:
+		if len(res.Accounts) == 0 {
+			return items, nil
+		}
+		fn := func() ([]*mastodon.Account, error) {
+			return res.Accounts, nil
+		}
+		return ac.getUserSimilar(fn, nil)
+	case SearchHashtags:
+		for _, t := range res.Hashtags {
+			items = append(items, NewTagItem(t))
+		}
+	}
+	return items, nil
+}
@@ -3,6 +3,7 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/util"
@@ -38,6 +39,19 @@ func toggleHelper(s *mastodon.Status, comp bool, on, off statusToggleFunc) (*mas
 	return ns, nil
 }
 
+// ResolveStatus finds the status with uri on the instance of ac, so ac can
+// interact with a status it has only seen through another account.
+func (ac *AccountClient) ResolveStatus(uri string) (*mastodon.Status, error) {
+	res, err := ac.Client.Search(context.Background(), uri, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(res.Statuses) == 0 {
+		return nil, fmt.Errorf("couldn't find the toot %s", uri)
+	}
+	return res.Statuses[0], nil
+}
+
 func (ac *AccountClient) BoostToggle(s *mastodon.Status) (*mastodon.Status, error) {
 	ns := util.StatusOrReblog(s)
 	reblogged := false
@@ -109,3 +123,11 @@ func (ac *AccountClient) DeleteStatus(s *mastodon.Status) error {
 func (ac *AccountClient) GetStatus(id mastodon.ID) (*mastodon.Status, error) {
 	return ac.Client.GetStatus(context.Background(), id)
 }
+
+// CanUseMedia reports if the media with id can be attached to a toot by ac.
+// Mastodon only lets the account that uploaded it use it, but other servers
+// can share it between the accounts of the server.
+func (ac *AccountClient) CanUseMedia(id mastodon.ID) bool {
+	var a mastodon.Attachment
+	return ac.doAPI(ac.Context(), http.MethodGet, fmt.Sprintf("/api/v1/media/%s", id), nil, &a, nil) == nil
+}
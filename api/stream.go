@@ -1,7 +1,6 @@
 package api
 
 import (
-	"context"
 	"sync"
 
 	"github.com/RasmusLindroth/go-mastodon"
@@ -17,6 +16,11 @@ const (
 	NotificationType
 	ListsType
 	TagType
+	ScheduledType
+	DraftType
+	FilterType
+	DomainType
+	LinkType
 )
 
 type StreamType uint
@@ -30,17 +34,50 @@ const (
 	ListStream
 )
 
+type StreamState uint
+
+const (
+	StreamConnecting StreamState = iota
+	StreamConnected
+	StreamDisconnected
+)
+
+// The number of failed connection attempts in a row before a stream is
+// treated as not working. Feeds fall back to polling when that happens.
+const streamFailLimit = 3
+
+func (s StreamState) String() string {
+	switch s {
+	case StreamConnecting:
+		return "connecting"
+	case StreamConnected:
+		return "connected"
+	case StreamDisconnected:
+		return "disconnected"
+	}
+	return ""
+}
+
 type Stream struct {
 	id        string
+	name      string
+	param     string
 	receivers []*Receiver
-	incoming  chan mastodon.Event
+	state     StreamState
+	err       error
+	failures  int
 	closed    bool
 	mux       sync.Mutex
 }
 
 type Receiver struct {
-	Ch     chan mastodon.Event
+	Ch chan mastodon.Event
+	// State gets a value each time the connection state of the stream
+	// changes. Use StreamState to get the current state.
+	State  chan struct{}
 	Closed bool
+	done   chan struct{}
+	stream *Stream
 	mux    sync.Mutex
 }
 
@@ -48,17 +85,51 @@ func (s *Stream) ID() string {
 	return s.id
 }
 
+func (s *Stream) State() (StreamState, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.state, s.err
+}
+
+// Failed returns true if the stream hasn't been able to connect the last
+// streamFailLimit attempts.
+func (s *Stream) Failed() bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.failures >= streamFailLimit
+}
+
 func (s *Stream) AddReceiver() *Receiver {
 	ch := make(chan mastodon.Event)
 	rec := &Receiver{
 		Ch:     ch,
+		State:  make(chan struct{}, 1),
 		Closed: false,
+		done:   make(chan struct{}),
+		stream: s,
 	}
+	s.mux.Lock()
 	s.receivers = append(s.receivers, rec)
+	s.mux.Unlock()
 	return rec
 }
 
+func (r *Receiver) StreamState() (StreamState, error) {
+	return r.stream.State()
+}
+
+func (r *Receiver) StreamFailed() bool {
+	return r.stream.Failed()
+}
+
+// Done is closed when the receiver is removed from the stream.
+func (r *Receiver) Done() <-chan struct{} {
+	return r.done
+}
+
 func (s *Stream) RemoveReceiver(r *Receiver) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
 	index := -1
 	for i, rec := range s.receivers {
 		if rec.Ch == r.Ch {
@@ -71,87 +142,120 @@ func (s *Stream) RemoveReceiver(r *Receiver) {
 	}
 	s.receivers[index].mux.Lock()
 	if !s.receivers[index].Closed {
-		close(s.receivers[index].Ch)
+		close(s.receivers[index].done)
 		s.receivers[index].Closed = true
 	}
 	s.receivers[index].mux.Unlock()
 	s.receivers = append(s.receivers[:index], s.receivers[index+1:]...)
 }
 
-func (s *Stream) listen() {
-	for e := range s.incoming {
-		switch e.(type) {
-		case *mastodon.UpdateEvent, *mastodon.ConversationEvent, *mastodon.NotificationEvent, *mastodon.DeleteEvent, *mastodon.ErrorEvent:
-			for _, r := range s.receivers {
-				go func(rec *Receiver, e mastodon.Event) {
-					rec.mux.Lock()
-					if rec.Closed {
-						return
-					}
-					rec.mux.Unlock()
-					rec.Ch <- e
-				}(r, e)
+func (s *Stream) setState(state StreamState, err error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if s.state == state && s.err == err {
+		return
+	}
+	switch state {
+	case StreamConnected:
+		s.failures = 0
+	case StreamDisconnected:
+		s.failures++
+	}
+	s.state = state
+	s.err = err
+	for _, r := range s.receivers {
+		r.mux.Lock()
+		if !r.Closed {
+			select {
+			case r.State <- struct{}{}:
+			default:
 			}
 		}
+		r.mux.Unlock()
 	}
 }
 
-func newStream(id string, inc chan mastodon.Event) (*Stream, *Receiver) {
-	stream := &Stream{
-		id:       id,
-		incoming: inc,
+// reject marks the stream as failed when the server won't let you subscribe
+// to it, so the feeds poll instead.
+func (s *Stream) reject(err error) {
+	s.mux.Lock()
+	s.failures = streamFailLimit - 1
+	s.mux.Unlock()
+	s.setState(StreamDisconnected, err)
+}
+
+func (s *Stream) send(e mastodon.Event) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	for _, r := range s.receivers {
+		go func(rec *Receiver, e mastodon.Event) {
+			select {
+			case rec.Ch <- e:
+			case <-rec.done:
+			}
+		}(r, e)
 	}
-	rec := stream.AddReceiver()
-	go stream.listen()
-	return stream, rec
 }
 
-func (ac *AccountClient) NewGenericStream(st StreamType, data string) (rec *Receiver, err error) {
-	var id string
+func streamID(st StreamType, data string) string {
 	switch st {
 	case HomeStream:
-		id = "HomeStream"
+		return "HomeStream"
 	case LocalStream:
-		id = "LocalStream"
+		return "LocalStream"
 	case FederatedStream:
-		id = "FederatedStream"
+		return "FederatedStream"
 	case DirectStream:
-		id = "DirectStream"
+		return "DirectStream"
 	case TagStream:
-		id = "TagStream" + data
+		return "TagStream" + data
 	case ListStream:
-		id = "ListStream" + data
+		return "ListStream" + data
 	default:
 		panic("invalid StreamType")
 	}
-	for _, s := range ac.Streams {
-		if s.ID() == id {
-			rec = s.AddReceiver()
-			return rec, nil
-		}
+}
+
+// NewGenericStream adds a receiver to a stream. All streams of an account
+// share one websocket, a new stream only subscribes to more events on it.
+func (ac *AccountClient) NewGenericStream(st StreamType, data string) (rec *Receiver, err error) {
+	id := streamID(st, data)
+	ac.streamMux.Lock()
+	defer ac.streamMux.Unlock()
+	if s, ok := ac.Streams[id]; ok {
+		return s.AddReceiver(), nil
 	}
-	var ch chan mastodon.Event
+	var name, param string
 	switch st {
 	case HomeStream:
-		ch, err = ac.WSClient.StreamingWSUser(context.Background())
+		name = "user"
 	case LocalStream:
-		ch, err = ac.WSClient.StreamingWSPublic(context.Background(), true)
+		name = "public:local"
 	case FederatedStream:
-		ch, err = ac.WSClient.StreamingWSPublic(context.Background(), false)
+		name = "public"
 	case DirectStream:
-		ch, err = ac.WSClient.StreamingWSDirect(context.Background())
+		name = "direct"
 	case TagStream:
-		ch, err = ac.WSClient.StreamingWSHashtag(context.Background(), data, false)
+		name, param = "hashtag", data
 	case ListStream:
-		ch, err = ac.WSClient.StreamingWSList(context.Background(), mastodon.ID(data))
-	default:
-		panic("invalid StreamType")
+		name, param = "list", data
 	}
-	if err != nil {
-		return nil, err
+	if ac.streamConn == nil {
+		ac.streamConn = newStreamConn(ac)
 	}
-	stream, rec := newStream(id, ch)
-	ac.Streams[stream.ID()] = stream
+	ac.streamConn.mux.Lock()
+	stream := &Stream{
+		id:       id,
+		name:     name,
+		param:    param,
+		state:    ac.streamConn.state,
+		err:      ac.streamConn.err,
+		failures: ac.streamConn.failures,
+	}
+	ac.streamConn.mux.Unlock()
+	rec = stream.AddReceiver()
+	ac.Streams[id] = stream
+	ac.streamConn.subscribe(stream)
 	return rec, nil
 }
 
@@ -185,35 +289,37 @@ func (ac *AccountClient) NewTagStream(tag string) (*Receiver, string, error) {
 	return rec, "public", err
 }
 
+// RemoveGenericReceiver removes a receiver from a stream. When the last
+// receiver is removed the stream is unsubscribed, and when there are no
+// streams left the websocket is closed.
 func (ac *AccountClient) RemoveGenericReceiver(rec *Receiver, st StreamType, data string) {
-	var id string
-	switch st {
-	case HomeStream:
-		id = "HomeStream"
-	case LocalStream:
-		id = "LocalStream"
-	case FederatedStream:
-		id = "FederatedStream"
-	case DirectStream:
-		id = "DirectStream"
-	case TagStream:
-		id = "TagStream" + data
-	case ListStream:
-		id = "ListStream" + data
-	default:
-		panic("invalid StreamType")
-	}
+	id := streamID(st, data)
+	ac.streamMux.Lock()
+	defer ac.streamMux.Unlock()
 	stream, ok := ac.Streams[id]
 	if !ok {
 		return
 	}
 	stream.RemoveReceiver(rec)
 	stream.mux.Lock()
-	if len(stream.receivers) == 0 && !stream.closed {
+	empty := len(stream.receivers) == 0 && !stream.closed
+	if empty {
 		stream.closed = true
-		delete(ac.Streams, id)
 	}
 	stream.mux.Unlock()
+	if !empty {
+		return
+	}
+	delete(ac.Streams, id)
+	if ac.streamConn == nil {
+		return
+	}
+	if len(ac.Streams) == 0 {
+		ac.streamConn.close()
+		ac.streamConn = nil
+		return
+	}
+	ac.streamConn.unsubscribe(stream)
 }
 
 func (ac *AccountClient) RemoveHomeReceiver(rec *Receiver) {
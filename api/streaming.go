@@ -0,0 +1,479 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	streamBackoffMin = time.Second
+	streamBackoffMax = 5 * time.Minute
+	// If the server hasn't sent anything, not even a ping, in this time the
+	// connection is treated as dead. This catches laptops that wake up from
+	// sleep with a connection that is long gone.
+	streamReadTimeout = 90 * time.Second
+	streamPingPeriod  = 30 * time.Second
+	streamWriteWait   = 10 * time.Second
+	// How long after a subscribe message the server can answer with an error
+	// for it. A subscription that works isn't answered at all, so the next
+	// one is sent when a stream gets an event or this has passed.
+	streamSubscribeWait = 10 * time.Second
+)
+
+// streamConn is the one websocket an account uses for all of its streams.
+// Streams are added and removed with subscribe and unsubscribe messages and
+// events are routed to them by the stream name in each message.
+type streamConn struct {
+	ac       *AccountClient
+	cancel   context.CancelFunc
+	conn     *websocket.Conn
+	state    StreamState
+	err      error
+	failures int
+	// pending is the stream that was subscribed to last, until it gets an
+	// event or streamSubscribeWait has passed. The server doesn't say which
+	// stream an error is for, so only one subscription is pending at a time.
+	pending *Stream
+	// answered tells writer that pending got an event or an error.
+	answered chan struct{}
+	// queued are the subscribe and unsubscribe messages, they're sent by
+	// writer so the UI doesn't wait for the websocket. wake tells writer
+	// there's something new in it.
+	queued   []streamWrite
+	wake     chan struct{}
+	mux      sync.Mutex
+	writeMux sync.Mutex
+}
+
+type streamWrite struct {
+	stream *Stream
+	t      string
+	// conn is the connection it was queued for, it's dropped if the
+	// connection has changed.
+	conn *websocket.Conn
+}
+
+type subscribeMessage struct {
+	Type   string `json:"type"`
+	Stream string `json:"stream"`
+	Tag    string `json:"tag,omitempty"`
+	List   string `json:"list,omitempty"`
+}
+
+func newStreamConn(ac *AccountClient) *streamConn {
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := &streamConn{
+		ac:       ac,
+		cancel:   cancel,
+		state:    StreamConnecting,
+		wake:     make(chan struct{}, 1),
+		answered: make(chan struct{}, 1),
+	}
+	go sc.run(ctx)
+	go sc.writer(ctx)
+	return sc
+}
+
+// current is false when the connection has been closed. It can still run for
+// a while, but it must not touch the streams as they may belong to a new
+// connection. Call it with ac.streamMux locked.
+func (sc *streamConn) current() bool {
+	return sc.ac.streamConn == sc
+}
+
+func (sc *streamConn) writer(ctx context.Context) {
+	for {
+		sc.mux.Lock()
+		if len(sc.queued) == 0 {
+			sc.mux.Unlock()
+			select {
+			case <-ctx.Done():
+				return
+			case <-sc.wake:
+			}
+			continue
+		}
+		w := sc.queued[0]
+		sc.queued = sc.queued[1:]
+		stale := w.conn != sc.conn
+		sc.mux.Unlock()
+		if stale || w.t != "subscribe" {
+			if !stale {
+				sc.write(w)
+			}
+			continue
+		}
+		select {
+		case <-sc.answered:
+		default:
+		}
+		if sc.write(w) != nil {
+			continue
+		}
+		state, err := sc.State()
+		sc.ac.streamMux.Lock()
+		if sc.current() {
+			w.stream.setState(state, err)
+		}
+		sc.ac.streamMux.Unlock()
+		select {
+		case <-sc.answered:
+		case <-time.After(streamSubscribeWait):
+		case <-ctx.Done():
+			return
+		}
+		sc.mux.Lock()
+		if sc.pending == w.stream {
+			sc.pending = nil
+		}
+		sc.mux.Unlock()
+	}
+}
+
+// queue never blocks, as it's called with ac.streamMux locked and writer
+// needs it too. Nothing is queued while it isn't connected, all streams are
+// subscribed when it connects.
+func (sc *streamConn) queue(s *Stream, t string) {
+	sc.mux.Lock()
+	if sc.conn == nil {
+		sc.mux.Unlock()
+		return
+	}
+	sc.queued = append(sc.queued, streamWrite{stream: s, t: t, conn: sc.conn})
+	sc.mux.Unlock()
+	select {
+	case sc.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (sc *streamConn) State() (StreamState, error) {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+	return sc.state, sc.err
+}
+
+func (sc *streamConn) close() {
+	sc.cancel()
+}
+
+// streamKey returns the key used to route messages to a stream. Mastodon
+// sends the stream name followed by the tag or list id.
+func streamKey(name, param string) string {
+	switch name {
+	case "hashtag", "hashtag:local":
+		param = strings.ToLower(param)
+	case "user:notification":
+		name = "user"
+	}
+	return name + " " + param
+}
+
+func (s *Stream) key() string {
+	return streamKey(s.name, s.param)
+}
+
+func (sc *streamConn) write(w streamWrite) error {
+	msg := subscribeMessage{
+		Type:   w.t,
+		Stream: w.stream.name,
+	}
+	switch w.stream.name {
+	case "hashtag", "hashtag:local":
+		msg.Tag = w.stream.param
+	case "list":
+		msg.List = w.stream.param
+	}
+	if w.t == "subscribe" {
+		sc.mux.Lock()
+		sc.pending = w.stream
+		sc.mux.Unlock()
+	}
+	sc.writeMux.Lock()
+	defer sc.writeMux.Unlock()
+	w.conn.SetWriteDeadline(time.Now().Add(streamWriteWait))
+	return w.conn.WriteJSON(msg)
+}
+
+// subscribed is called when s gets an event, so it's no longer pending.
+func (sc *streamConn) subscribed(s *Stream) {
+	sc.mux.Lock()
+	defer sc.mux.Unlock()
+	if sc.pending == s {
+		sc.pending = nil
+		sc.answer()
+	}
+}
+
+// rejected marks the pending subscription as failed. Errors that come after
+// streamSubscribeWait aren't for a subscription and are ignored.
+func (sc *streamConn) rejected(err error) {
+	sc.mux.Lock()
+	stream := sc.pending
+	sc.pending = nil
+	if stream != nil {
+		sc.answer()
+	}
+	sc.mux.Unlock()
+	if stream != nil {
+		stream.reject(err)
+	}
+}
+
+func (sc *streamConn) answer() {
+	select {
+	case sc.answered <- struct{}{}:
+	default:
+	}
+}
+
+func (sc *streamConn) subscribe(s *Stream) {
+	sc.queue(s, "subscribe")
+}
+
+func (sc *streamConn) unsubscribe(s *Stream) {
+	sc.queue(s, "unsubscribe")
+}
+
+func (sc *streamConn) setState(state StreamState, err error, conn *websocket.Conn) {
+	sc.mux.Lock()
+	switch state {
+	case StreamConnected:
+		sc.failures = 0
+	case StreamDisconnected:
+		sc.failures++
+	}
+	sc.state = state
+	sc.err = err
+	sc.conn = conn
+	sc.pending = nil
+	sc.mux.Unlock()
+	sc.ac.streamMux.Lock()
+	defer sc.ac.streamMux.Unlock()
+	if !sc.current() {
+		return
+	}
+	for _, s := range sc.ac.Streams {
+		s.setState(state, err)
+	}
+}
+
+// subscribeAll queues all streams of the account, it's done each time the
+// websocket connects.
+func (sc *streamConn) subscribeAll() {
+	sc.ac.streamMux.Lock()
+	defer sc.ac.streamMux.Unlock()
+	if !sc.current() {
+		return
+	}
+	for _, s := range sc.ac.Streams {
+		sc.queue(s, "subscribe")
+	}
+}
+
+// run keeps the websocket connected until it's closed. When the connection
+// drops it reconnects with exponential backoff.
+func (sc *streamConn) run(ctx context.Context) {
+	backoff := streamBackoffMin
+	for {
+		sc.setState(StreamConnecting, nil, nil)
+		conn, err := sc.ac.dialStream(ctx)
+		if err == nil {
+			sc.setState(StreamConnected, nil, conn)
+			sc.subscribeAll()
+			started := time.Now()
+			err = sc.read(ctx, conn)
+			if time.Since(started) > streamBackoffMax {
+				backoff = streamBackoffMin
+			}
+		}
+		if conn != nil {
+			conn.Close()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		sc.setState(StreamDisconnected, err, nil)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return
+		}
+		backoff *= 2
+		if backoff > streamBackoffMax {
+			backoff = streamBackoffMax
+		}
+	}
+}
+
+func (sc *streamConn) read(ctx context.Context, conn *websocket.Conn) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(streamPingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				conn.Close()
+				return
+			case <-done:
+				return
+			case <-ticker.C:
+				conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(streamWriteWait))
+			}
+		}
+	}()
+	extend := func() {
+		conn.SetReadDeadline(time.Now().Add(streamReadTimeout))
+	}
+	extend()
+	conn.SetPongHandler(func(string) error {
+		extend()
+		return nil
+	})
+	conn.SetPingHandler(func(data string) error {
+		extend()
+		err := conn.WriteControl(websocket.PongMessage, []byte(data), time.Now().Add(streamWriteWait))
+		if err == websocket.ErrCloseSent {
+			return nil
+		}
+		return err
+	})
+	for {
+		var msg streamMessage
+		err := conn.ReadJSON(&msg)
+		if err != nil {
+			return err
+		}
+		extend()
+		if msg.Error != "" {
+			sc.rejected(errors.New(msg.Error))
+			continue
+		}
+		if len(msg.Stream) == 0 {
+			continue
+		}
+		e := msg.event()
+		if e == nil {
+			continue
+		}
+		var param string
+		if len(msg.Stream) > 1 {
+			param = msg.Stream[1]
+		}
+		key := streamKey(msg.Stream[0], param)
+		sc.ac.streamMux.Lock()
+		var stream *Stream
+		for _, s := range sc.ac.Streams {
+			if s.key() == key && sc.current() {
+				stream = s
+				break
+			}
+		}
+		sc.ac.streamMux.Unlock()
+		if stream != nil {
+			sc.subscribed(stream)
+			stream.send(e)
+		}
+	}
+}
+
+type streamMessage struct {
+	Stream  []string    `json:"stream"`
+	Event   string      `json:"event"`
+	Payload interface{} `json:"payload"`
+	// Error is set when the server rejects a subscription, e.g. if the public
+	// timelines are disabled.
+	Error string `json:"error"`
+}
+
+// event turns a message from the streaming API into the same events that
+// go-mastodon uses. Unknown events return nil.
+func (m *streamMessage) event() mastodon.Event {
+	payload, _ := m.Payload.(string)
+	switch m.Event {
+	case "update":
+		var status mastodon.Status
+		if json.Unmarshal([]byte(payload), &status) == nil {
+			return &mastodon.UpdateEvent{Status: &status}
+		}
+	case "status.update":
+		var status mastodon.Status
+		if json.Unmarshal([]byte(payload), &status) == nil {
+			return &mastodon.UpdateEditEvent{Status: &status}
+		}
+	case "notification":
+		var notification mastodon.Notification
+		if json.Unmarshal([]byte(payload), &notification) == nil {
+			return &mastodon.NotificationEvent{Notification: &notification}
+		}
+	case "conversation":
+		var conversation mastodon.Conversation
+		if json.Unmarshal([]byte(payload), &conversation) == nil {
+			return &mastodon.ConversationEvent{Conversation: &conversation}
+		}
+	case "delete":
+		if f, ok := m.Payload.(float64); ok {
+			return &mastodon.DeleteEvent{ID: mastodon.ID(fmt.Sprint(int64(f)))}
+		}
+		return &mastodon.DeleteEvent{ID: mastodon.ID(strings.TrimSpace(payload))}
+	}
+	return nil
+}
+
+// dialStream opens a websocket to the streaming API without any stream, they
+// are added with subscribe messages. It follows redirects as some instances
+// serve the streaming API from another host, but without the token if the
+// host changes.
+func (ac *AccountClient) dialStream(ctx context.Context) (*websocket.Conn, error) {
+	u, err := url.Parse(ac.Client.Config.Server)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/api/v1/streaming")
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+ac.Client.Config.AccessToken)
+
+	for i := 0; i < 5; i++ {
+		switch u.Scheme {
+		case "http":
+			u.Scheme = "ws"
+		case "https":
+			u.Scheme = "wss"
+		}
+		conn, resp, err := ac.WSClient.DialContext(ctx, u.String(), header)
+		if err == nil {
+			return conn, nil
+		}
+		if resp == nil || err != websocket.ErrBadHandshake {
+			return nil, err
+		}
+		resp.Body.Close()
+		loc := resp.Header.Get("Location")
+		if loc == "" {
+			return nil, fmt.Errorf("couldn't connect to the stream: %s", resp.Status)
+		}
+		next, err := u.Parse(loc)
+		if err != nil {
+			return nil, err
+		}
+		// The token is only sent to the host of your instance.
+		if next.Host != u.Host {
+			header.Del("Authorization")
+		}
+		u = next
+	}
+	return nil, errors.New("couldn't connect to the stream: too many redirects")
+}
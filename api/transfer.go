@@ -0,0 +1,313 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// TransferKinds are what can be exported to and imported from CSV files. The
+// files use the same format as the ones in Mastodon's web UI.
+var TransferKinds = []string{
+	"following",
+	"blocks",
+	"mutes",
+	"domain-blocks",
+	"lists",
+	"bookmarks",
+}
+
+const transferLimit = 80
+
+// ValidTransferKind returns an error if kind isn't one of TransferKinds.
+func ValidTransferKind(kind string) error {
+	for _, k := range TransferKinds {
+		if k == kind {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown kind %s, use one of %s", kind, strings.Join(TransferKinds, ", "))
+}
+
+// TransferHeader returns the header row of the CSV file for kind, or nil if
+// the file doesn't have one.
+func TransferHeader(kind string) []string {
+	switch kind {
+	case "following":
+		return []string{"Account address", "Show boosts", "Notify on new posts", "Languages"}
+	case "mutes":
+		return []string{"Account address", "Hide notifications"}
+	}
+	return nil
+}
+
+// transferRelationship has the fields go-mastodon doesn't decode.
+type transferRelationship struct {
+	ID                  mastodon.ID `json:"id"`
+	ShowingReblogs      bool        `json:"showing_reblogs"`
+	Notifying           bool        `json:"notifying"`
+	MutingNotifications bool        `json:"muting_notifications"`
+	Languages           []string    `json:"languages"`
+}
+
+func (ac *AccountClient) transferRelationships(accs []*mastodon.Account) (map[mastodon.ID]*transferRelationship, error) {
+	rels := make(map[mastodon.ID]*transferRelationship)
+	if len(accs) == 0 {
+		return rels, nil
+	}
+	params := url.Values{}
+	for _, a := range accs {
+		params.Add("id[]", string(a.ID))
+	}
+	var res []*transferRelationship
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/accounts/relationships", params, &res, nil)
+	if err != nil {
+		return rels, err
+	}
+	for _, r := range res {
+		rels[r.ID] = r
+	}
+	return rels, nil
+}
+
+func (ac *AccountClient) transferAccounts(uri string, cursor string) ([]*mastodon.Account, string, error) {
+	var accs []*mastodon.Account
+	pg := &mastodon.Pagination{MaxID: mastodon.ID(cursor), Limit: transferLimit}
+	err := ac.doAPI(ac.Context(), http.MethodGet, uri, nil, &accs, pg)
+	if err != nil || len(accs) == 0 {
+		return accs, "", err
+	}
+	return accs, string(pg.MaxID), nil
+}
+
+// ExportPage returns the CSV rows of one page of kind, starting at cursor.
+// An empty cursor is the first page. next is the cursor of the next page and
+// is empty after the last page.
+func (ac *AccountClient) ExportPage(kind string, cursor string) (rows [][]string, next string, err error) {
+	if err := ValidTransferKind(kind); err != nil {
+		return nil, "", err
+	}
+	switch kind {
+	case "following", "blocks", "mutes":
+		uri := fmt.Sprintf("/api/v1/accounts/%s/following", ac.Me.ID)
+		if kind == "blocks" {
+			uri = "/api/v1/blocks"
+		} else if kind == "mutes" {
+			uri = "/api/v1/mutes"
+		}
+		accs, next, err := ac.transferAccounts(uri, cursor)
+		if err != nil {
+			return nil, "", err
+		}
+		rels := make(map[mastodon.ID]*transferRelationship)
+		if kind != "blocks" {
+			rels, err = ac.transferRelationships(accs)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		for _, a := range accs {
+			acct := ac.FullAcct(a)
+			r, ok := rels[a.ID]
+			switch {
+			case kind == "blocks":
+				rows = append(rows, []string{acct})
+			case kind == "mutes" && ok:
+				rows = append(rows, []string{acct, fmt.Sprint(r.MutingNotifications)})
+			case kind == "mutes":
+				rows = append(rows, []string{acct, "true"})
+			case ok:
+				rows = append(rows, []string{acct, fmt.Sprint(r.ShowingReblogs), fmt.Sprint(r.Notifying), strings.Join(r.Languages, ", ")})
+			default:
+				rows = append(rows, []string{acct, "true", "false", ""})
+			}
+		}
+		return rows, next, nil
+	case "domain-blocks":
+		pg := &mastodon.Pagination{MaxID: mastodon.ID(cursor), Limit: 200}
+		domains, err := ac.getDomainBlocks(pg)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, d := range domains {
+			rows = append(rows, []string{d})
+		}
+		if len(domains) > 0 {
+			next = string(pg.MaxID)
+		}
+		return rows, next, nil
+	case "lists":
+		var lists []*List
+		err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/lists", nil, &lists, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		i, _ := strconv.Atoi(cursor)
+		if i >= len(lists) {
+			return nil, "", nil
+		}
+		params := url.Values{}
+		params.Set("limit", "0")
+		var accs []*mastodon.Account
+		err = ac.doAPI(ac.Context(), http.MethodGet, fmt.Sprintf("/api/v1/lists/%s/accounts", lists[i].ID), params, &accs, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, a := range accs {
+			rows = append(rows, []string{lists[i].Title, ac.FullAcct(a)})
+		}
+		if i+1 < len(lists) {
+			next = strconv.Itoa(i + 1)
+		}
+		return rows, next, nil
+	case "bookmarks":
+		var statuses []*mastodon.Status
+		pg := &mastodon.Pagination{MaxID: mastodon.ID(cursor), Limit: 40}
+		err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/bookmarks", nil, &statuses, pg)
+		if err != nil {
+			return nil, "", err
+		}
+		for _, s := range statuses {
+			rows = append(rows, []string{s.URI})
+		}
+		if len(statuses) > 0 {
+			next = string(pg.MaxID)
+		}
+		return rows, next, nil
+	}
+	return nil, "", nil
+}
+
+// Importer imports the rows of a CSV file of one kind.
+type Importer struct {
+	ac    *AccountClient
+	kind  string
+	lists map[string]*mastodon.List
+}
+
+func (ac *AccountClient) NewImporter(kind string) (*Importer, error) {
+	if err := ValidTransferKind(kind); err != nil {
+		return nil, err
+	}
+	return &Importer{ac: ac, kind: kind}, nil
+}
+
+// IsHeader reports if row is the header row of the file.
+func (imp *Importer) IsHeader(row []string) bool {
+	h := TransferHeader(imp.kind)
+	return len(h) > 0 && len(row) > 0 && strings.EqualFold(strings.TrimSpace(row[0]), h[0])
+}
+
+func rowBool(row []string, i int, def bool) bool {
+	if len(row) <= i {
+		return def
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(row[i]))
+	if err != nil {
+		return def
+	}
+	return b
+}
+
+// list returns the list named title and creates it if it doesn't exist.
+func (imp *Importer) list(title string) (*mastodon.List, error) {
+	if imp.lists == nil {
+		var lists []*List
+		err := imp.ac.doAPI(imp.ac.Context(), http.MethodGet, "/api/v1/lists", nil, &lists, nil)
+		if err != nil {
+			return nil, err
+		}
+		imp.lists = make(map[string]*mastodon.List)
+		for _, l := range lists {
+			imp.lists[l.Title] = &l.List
+		}
+	}
+	if l, ok := imp.lists[title]; ok {
+		return l, nil
+	}
+	l, err := imp.ac.CreateList(title)
+	if err != nil {
+		return nil, err
+	}
+	imp.lists[title] = &l.List
+	return &l.List, nil
+}
+
+// Import imports one row. Empty rows are skipped.
+func (imp *Importer) Import(row []string) error {
+	if len(row) == 0 || strings.TrimSpace(row[0]) == "" {
+		return nil
+	}
+	ac := imp.ac
+	first := strings.TrimSpace(row[0])
+	switch imp.kind {
+	case "following":
+		u, err := ac.LookupAccount(first)
+		if err != nil {
+			return err
+		}
+		if len(row) < 2 {
+			_, err = ac.FollowUser(u)
+			return err
+		}
+		var langs []string
+		if len(row) > 3 {
+			for _, l := range strings.Split(row[3], ",") {
+				if l = strings.TrimSpace(l); l != "" {
+					langs = append(langs, l)
+				}
+			}
+		}
+		_, err = ac.FollowUserOptions(u, rowBool(row, 1, true), rowBool(row, 2, false), langs)
+		return err
+	case "blocks":
+		u, err := ac.LookupAccount(first)
+		if err != nil {
+			return err
+		}
+		_, err = ac.BlockUser(u)
+		return err
+	case "mutes":
+		u, err := ac.LookupAccount(first)
+		if err != nil {
+			return err
+		}
+		if rowBool(row, 1, true) {
+			_, err = ac.MuteUser(u)
+		} else {
+			_, err = ac.MuteUserNotifications(u, false)
+		}
+		return err
+	case "domain-blocks":
+		return ac.BlockDomain(first)
+	case "lists":
+		if len(row) < 2 {
+			return fmt.Errorf("the row for list %s has no account", first)
+		}
+		l, err := imp.list(first)
+		if err != nil {
+			return err
+		}
+		u, err := ac.LookupAccount(row[1])
+		if err != nil {
+			return err
+		}
+		return ac.AddUserToList(u, l)
+	case "bookmarks":
+		res, err := ac.Client.Search(context.Background(), first, true)
+		if err != nil {
+			return err
+		}
+		if len(res.Statuses) == 0 {
+			return fmt.Errorf("couldn't find the toot %s", first)
+		}
+		_, err = ac.Bookmark(res.Statuses[0])
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
+
+// TrendingKinds are the trends you can open with :trending.
+var TrendingKinds = []string{
+	"statuses",
+	"tags",
+	"links",
+}
+
+const trendsLimit = 20
+
+// TrendingLink is a link that is shared a lot right now.
+type TrendingLink struct {
+	mastodon.Card
+	History []mastodon.History `json:"history"`
+}
+
+func trendsParams(offset int) url.Values {
+	params := url.Values{}
+	params.Set("limit", fmt.Sprint(trendsLimit))
+	params.Set("offset", fmt.Sprint(offset))
+	return params
+}
+
+func (ac *AccountClient) GetTrendingStatuses(offset int) ([]Item, error) {
+	var items []Item
+	var statuses []*mastodon.Status
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/trends/statuses", trendsParams(offset), &statuses, nil)
+	if err != nil {
+		return items, err
+	}
+	for _, s := range statuses {
+		items = append(items, NewStatusItem(s, false))
+	}
+	return items, nil
+}
+
+func (ac *AccountClient) GetTrendingTags(offset int) ([]Item, error) {
+	var items []Item
+	var tags []*mastodon.Tag
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/trends/tags", trendsParams(offset), &tags, nil)
+	if err != nil {
+		return items, err
+	}
+	for _, t := range tags {
+		items = append(items, NewTagItem(t))
+	}
+	return items, nil
+}
+
+func (ac *AccountClient) GetTrendingLinks(offset int) ([]Item, error) {
+	var items []Item
+	var links []*TrendingLink
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/trends/links", trendsParams(offset), &links, nil)
+	if err != nil {
+		return items, err
+	}
+	for _, l := range links {
+		items = append(items, NewLinkItem(l))
+	}
+	return items, nil
+}
+
+// SupportsLinkTimeline reports if the instance can list the statuses that
+// shared a link. It was added in Mastodon 4.3.
+func (ac *AccountClient) SupportsLinkTimeline() bool {
+	if ac.Instance == nil {
+		return false
+	}
+	parts := strings.SplitN(ac.Instance.Version, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+	return major > 4 || (major == 4 && minor >= 3)
+}
+
+func (ac *AccountClient) GetLinkStatuses(pg *mastodon.Pagination, link string) ([]Item, error) {
+	var items []Item
+	params := url.Values{}
+	params.Set("url", link)
+	var statuses []*mastodon.Status
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/timelines/link", params, &statuses, pg)
+	if err != nil {
+		return items, err
+	}
+	for _, s := range statuses {
+		items = append(items, NewStatusItem(s, false))
+	}
+	return items, nil
+}
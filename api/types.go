@@ -1,6 +1,12 @@
 package api
 
-import "github.com/RasmusLindroth/go-mastodon"
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+)
 
 type RequestData struct {
 	MinID mastodon.ID
@@ -14,6 +20,12 @@ type AccountClient struct {
 	WSClient    *mastodon.WSClient
 	InstanceOld *mastodon.Instance
 	Instance    *mastodon.InstanceV2
+	streamConn  *streamConn
+	streamMux   sync.Mutex
+	rateLimit   rateLimit
+	// ctx and retryWait are set by SetBatch.
+	ctx       context.Context
+	retryWait time.Duration
 }
 
 type User struct {
@@ -3,6 +3,9 @@ package api
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/RasmusLindroth/go-mastodon"
 )
@@ -38,6 +41,23 @@ func (ac *AccountClient) FollowUser(u *mastodon.Account) (*mastodon.Relationship
 	return ac.Client.AccountFollow(context.Background(), u.ID)
 }
 
+// FollowUserOptions follows u and sets if boosts from u are shown, if you
+// get notified when u posts and which languages you see.
+func (ac *AccountClient) FollowUserOptions(u *mastodon.Account, reblogs bool, notify bool, languages []string) (*mastodon.Relationship, error) {
+	params := url.Values{}
+	params.Set("reblogs", fmt.Sprint(reblogs))
+	params.Set("notify", fmt.Sprint(notify))
+	for _, l := range languages {
+		params.Add("languages[]", l)
+	}
+	var rel mastodon.Relationship
+	err := ac.doAPI(ac.Context(), http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/follow", u.ID), params, &rel, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
 func (ac *AccountClient) UnfollowUser(u *mastodon.Account) (*mastodon.Relationship, error) {
 	return ac.Client.AccountUnfollow(context.Background(), u.ID)
 }
@@ -68,6 +88,18 @@ func (ac *AccountClient) MuteUser(u *mastodon.Account) (*mastodon.Relationship,
 	return ac.Client.AccountMute(context.Background(), u.ID)
 }
 
+// MuteUserNotifications mutes u and sets if notifications from u are hidden.
+func (ac *AccountClient) MuteUserNotifications(u *mastodon.Account, notifications bool) (*mastodon.Relationship, error) {
+	params := url.Values{}
+	params.Set("notifications", fmt.Sprint(notifications))
+	var rel mastodon.Relationship
+	err := ac.doAPI(ac.Context(), http.MethodPost, fmt.Sprintf("/api/v1/accounts/%s/mute", u.ID), params, &rel, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &rel, nil
+}
+
 func (ac *AccountClient) UnmuteUser(u *mastodon.Account) (*mastodon.Relationship, error) {
 	return ac.Client.AccountUnmute(context.Background(), u.ID)
 }
@@ -95,3 +127,39 @@ func (ac *AccountClient) AddUserToList(u *mastodon.Account, l *mastodon.List) er
 func (ac *AccountClient) DeleteUserFromList(u *mastodon.Account, l *mastodon.List) error {
 	return ac.Client.RemoveFromList(context.Background(), l.ID, u.ID)
 }
+
+// LookupAccount finds an account by its address, e.g. tut@fosstodon.org.
+// Accounts the instance doesn't know about yet are resolved with a search.
+func (ac *AccountClient) LookupAccount(acct string) (*mastodon.Account, error) {
+	acct = strings.TrimPrefix(strings.TrimSpace(acct), "@")
+	params := url.Values{}
+	params.Set("acct", acct)
+	var a mastodon.Account
+	err := ac.doAPI(ac.Context(), http.MethodGet, "/api/v1/accounts/lookup", params, &a, nil)
+	if err == nil {
+		return &a, nil
+	}
+	accs, err := ac.Client.AccountsSearchResolve(context.Background(), acct, 1, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range accs {
+		if strings.EqualFold(a.Acct, acct) || strings.EqualFold(ac.FullAcct(a), acct) {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find the account %s", acct)
+}
+
+// FullAcct returns the address of a with the domain included, also for
+// local accounts.
+func (ac *AccountClient) FullAcct(a *mastodon.Account) string {
+	if strings.Contains(a.Acct, "@") {
+		return a.Acct
+	}
+	u, err := url.Parse(a.URL)
+	if err != nil || u.Host == "" {
+		return a.Acct
+	}
+	return fmt.Sprintf("%s@%s", a.Acct, u.Host)
+}
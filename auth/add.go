@@ -6,12 +6,16 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"strings"
+	"time"
 
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/util"
 )
 
+// How long to wait for the browser to be redirected back before you have to
+// paste the code instead.
+const LoopbackTimeout = 5 * time.Minute
+
 func AddAccount(ad *AccountData) *mastodon.Client {
 	reader := bufio.NewReader(os.Stdin)
 	fmt.Println("You will have to log in to your Mastodon instance to be able")
@@ -27,13 +31,7 @@ func AddAccount(ad *AccountData) *mastodon.Client {
 		if err != nil {
 			log.Fatalln(err)
 		}
-		if !(strings.HasPrefix(server, "https://") || strings.HasPrefix(server, "http://")) {
-			server = "https://" + server
-		}
-		client := mastodon.NewClient(&mastodon.Config{
-			Server: server,
-		})
-		_, err = client.GetInstance(context.Background())
+		server, err = CheckServer(server)
 		if err != nil {
 			fmt.Printf("\nCouldn't connect to instance %s:\n%s\nTry again or press ^C.\n", server, err)
 			fmt.Println("--------------------------------------------------------------")
@@ -41,72 +39,54 @@ func AddAccount(ad *AccountData) *mastodon.Client {
 			break
 		}
 	}
-	srv, err := mastodon.RegisterApp(context.Background(), &mastodon.AppConfig{
-		Server:       server,
-		ClientName:   "tut-tui",
-		Scopes:       "read write follow",
-		RedirectURIs: "urn:ietf:wg:oauth:2.0:oob",
-		Website:      "https://github.com/RasmusLindroth/tut",
-	})
+	login, err := NewLogin(server, !Headless())
 	if err != nil {
 		fmt.Printf("Couldn't register the app. Error: %v\n\nExiting...\n", err)
 		os.Exit(1)
 	}
-
-	util.OpenURL(srv.AuthURI)
-	fmt.Println("You need to authorize Tut to use your account. Your browser")
-	fmt.Println("should've opened. If not you can use the URL below.")
-	fmt.Printf("\n%s\n\n", srv.AuthURI)
+	defer login.Close()
 
 	var client *mastodon.Client
-	for {
-		var err error
+	if login.Loopback() {
+		util.OpenURL(login.AuthURL)
+		fmt.Println("You need to authorize Tut to use your account. Your browser")
+		fmt.Println("should've opened. If not you can use the URL below. Tut")
+		fmt.Println("continues by itself when you're done.")
+		fmt.Printf("\n%s\n\n", login.AuthURL)
+		ctx, cancel := context.WithTimeout(context.Background(), LoopbackTimeout)
+		client, err = login.Wait(ctx)
+		cancel()
+		if err != nil {
+			fmt.Printf("Couldn't log in with the browser. Error: %v\n", err)
+			fmt.Println("You can paste an authorization code instead.")
+			fmt.Println("--------------------------------------------------------------")
+			login.UseOOB()
+		}
+	}
+	if client == nil {
+		util.OpenURL(login.AuthURL)
+		fmt.Println("You need to authorize Tut to use your account. Your browser")
+		fmt.Println("should've opened. If not you can use the URL below.")
+		fmt.Printf("\n%s\n\n", login.AuthURL)
+	}
+	for client == nil {
 		fmt.Print("Authorization code: ")
 		code, err := util.ReadLine(reader)
 		if err != nil {
 			log.Fatalln(err)
 		}
-		client = mastodon.NewClient(&mastodon.Config{
-			Server:       server,
-			ClientID:     srv.ClientID,
-			ClientSecret: srv.ClientSecret,
-		})
-
-		err = client.AuthenticateToken(context.Background(), code, "urn:ietf:wg:oauth:2.0:oob")
+		client, err = login.Exchange(context.Background(), code)
 		if err != nil {
 			fmt.Printf("\nError: %v\nTry again or press ^C.\n", err)
 			fmt.Println("--------------------------------------------------------------")
-		} else {
-			break
 		}
 	}
-	me, err := client.GetAccountCurrentUser(context.Background())
-	if err != nil {
-		fmt.Printf("\nCouldn't get user. Error: %v\nExiting...\n", err)
-		os.Exit(1)
-	}
-	acc := Account{
-		Name:         me.Username,
-		Server:       client.Config.Server,
-		ClientID:     client.Config.ClientID,
-		ClientSecret: client.Config.ClientSecret,
-		AccessToken:  client.Config.AccessToken,
-	}
 	if ad == nil {
-		ad = &AccountData{
-			Accounts: []Account{acc},
-		}
-	} else {
-		ad.Accounts = append(ad.Accounts, acc)
-	}
-	path, _, err := util.CheckConfig("accounts.toml")
-	if err != nil {
-		fmt.Printf("Couldn't open the account file for reading. Error: %v\n", err)
-		os.Exit(1)
+		ad = &AccountData{}
 	}
-	err = ad.Save(path)
+	_, err = SaveAccount(ad, client)
 	if err != nil {
-		fmt.Printf("Couldn't update the account file. Error: %v\n", err)
+		fmt.Printf("Couldn't save the account. Error: %v\n", err)
 		os.Exit(1)
 	}
 	return client
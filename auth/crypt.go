@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/term"
+)
+
+// ErrPassphrase is returned when the accounts file can't be decrypted with
+// the passphrase you entered.
+var ErrPassphrase = errors.New("wrong passphrase")
+
+// How many times you can enter the passphrase before tut gives up.
+const passphraseTries = 3
+
+// encryptedAccounts are the accounts encrypted with XChaCha20-Poly1305. The
+// key is derived from the passphrase with Argon2id, with the parameters RFC
+// 9106 recommends for 64 MiB. They're saved so they can be raised later.
+type encryptedAccounts struct {
+	KDF     string
+	Time    uint32
+	Memory  uint32
+	Threads uint8
+	Salt    string
+	Nonce   string
+	Data    string
+}
+
+func encryptAccounts(data []byte, passphrase []byte) (*encryptedAccounts, error) {
+	e := &encryptedAccounts{
+		KDF:     "argon2id",
+		Time:    3,
+		Memory:  64 * 1024,
+		Threads: 4,
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(e.key(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	e.Salt = base64.StdEncoding.EncodeToString(salt)
+	e.Nonce = base64.StdEncoding.EncodeToString(nonce)
+	e.Data = base64.StdEncoding.EncodeToString(aead.Seal(nil, nonce, data, nil))
+	return e, nil
+}
+
+func (e *encryptedAccounts) key(passphrase []byte, salt []byte) []byte {
+	return argon2.IDKey(passphrase, salt, e.Time, e.Memory, e.Threads, chacha20poly1305.KeySize)
+}
+
+func (e *encryptedAccounts) decrypt(passphrase []byte) ([]byte, error) {
+	if e.KDF != "argon2id" {
+		return nil, fmt.Errorf("the accounts file is encrypted with %s that tut doesn't support", e.KDF)
+	}
+	salt, err := base64.StdEncoding.DecodeString(e.Salt)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := base64.StdEncoding.DecodeString(e.Nonce)
+	if err != nil {
+		return nil, err
+	}
+	data, err := base64.StdEncoding.DecodeString(e.Data)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := chacha20poly1305.NewX(e.key(passphrase, salt))
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, errors.New("the accounts file has an invalid nonce")
+	}
+	plain, err := aead.Open(nil, nonce, data, nil)
+	if err != nil {
+		return nil, ErrPassphrase
+	}
+	return plain, nil
+}
+
+// open asks for the passphrase until the accounts can be decrypted.
+func (e *encryptedAccounts) open() ([]byte, []byte, error) {
+	for i := 0; i < passphraseTries; i++ {
+		passphrase, err := readPassphrase("Passphrase for your accounts: ")
+		if err != nil {
+			return nil, nil, err
+		}
+		plain, err := e.decrypt(passphrase)
+		if err == nil {
+			return plain, passphrase, nil
+		}
+		if !errors.Is(err, ErrPassphrase) {
+			return nil, nil, err
+		}
+		fmt.Println("Wrong passphrase, try again.")
+	}
+	return nil, nil, ErrPassphrase
+}
+
+func readPassphrase(prompt string) ([]byte, error) {
+	fmt.Print(prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read the passphrase: %v", err)
+	}
+	return passphrase, nil
+}
+
+// newPassphrase asks for a new passphrase twice to make sure there isn't a
+// typo in it.
+func newPassphrase() ([]byte, error) {
+	for {
+		passphrase, err := readPassphrase("New passphrase for your accounts: ")
+		if err != nil {
+			return nil, err
+		}
+		if len(passphrase) == 0 {
+			fmt.Println("The passphrase can't be empty.")
+			continue
+		}
+		again, err := readPassphrase("Repeat the passphrase: ")
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(passphrase, again) {
+			return passphrase, nil
+		}
+		fmt.Println("The passphrases don't match, try again.")
+	}
+}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"os"
@@ -10,6 +12,14 @@ import (
 	"github.com/pelletier/go-toml/v2"
 )
 
+// accountsFile is how accounts.toml is written. The accounts are either in
+// Accounts or encrypted in Encrypted.
+type accountsFile struct {
+	Backend   string             `toml:",omitempty"`
+	Accounts  []Account          `toml:",omitempty"`
+	Encrypted *encryptedAccounts `toml:",omitempty"`
+}
+
 func GetSecret(s string) string {
 	var err error
 	if strings.HasPrefix(s, "!CMD!") {
@@ -31,10 +41,33 @@ func GetAccounts(filepath string) (*AccountData, error) {
 	if err != nil {
 		return &AccountData{}, err
 	}
-	accounts := &AccountData{}
-	err = toml.Unmarshal(data, accounts)
+	file := accountsFile{}
+	err = toml.Unmarshal(data, &file)
+	accounts := &AccountData{
+		Backend:  file.Backend,
+		Accounts: file.Accounts,
+	}
+	if err == nil && file.Encrypted != nil {
+		var plain []byte
+		plain, accounts.passphrase, err = file.Encrypted.open()
+		if err != nil {
+			return accounts, err
+		}
+		decrypted := accountsFile{}
+		err = toml.Unmarshal(plain, &decrypted)
+		accounts.Accounts = decrypted.Accounts
+	}
 
 	for i, acc := range accounts.Accounts {
+		if strings.HasPrefix(acc.ClientID, "!CMD!") ||
+			strings.HasPrefix(acc.ClientSecret, "!CMD!") ||
+			strings.HasPrefix(acc.AccessToken, "!CMD!") {
+			accounts.Accounts[i].stored = &secrets{
+				ClientID:     acc.ClientID,
+				ClientSecret: acc.ClientSecret,
+				AccessToken:  acc.AccessToken,
+			}
+		}
 		accounts.Accounts[i].ClientID = GetSecret(acc.ClientID)
 		accounts.Accounts[i].ClientSecret = GetSecret(acc.ClientSecret)
 		accounts.Accounts[i].AccessToken = GetSecret(acc.AccessToken)
@@ -43,17 +76,188 @@ func GetAccounts(filepath string) (*AccountData, error) {
 	return accounts, err
 }
 
+// BackendName returns where the secrets of the accounts are kept, an empty
+// Backend is plain.
+func (ad *AccountData) BackendName() string {
+	if ad.Backend == "" {
+		return BackendPlain
+	}
+	return ad.Backend
+}
+
+// storedAccounts returns the accounts as they're written to the file. With a
+// keyring the secrets that aren't in it yet are added to it.
+func (ad *AccountData) storedAccounts() ([]Account, error) {
+	kr := keyringFor(ad.BackendName())
+	accounts := make([]Account, len(ad.Accounts))
+	for i := range ad.Accounts {
+		acc := &ad.Accounts[i]
+		if kr != nil && acc.stored == nil {
+			s, err := storeSecrets(kr, *acc)
+			if err != nil {
+				return nil, fmt.Errorf("couldn't add %s to %s: %v", acc.Name, ad.BackendName(), err)
+			}
+			acc.stored = s
+		}
+		stored := *acc
+		if acc.stored != nil {
+			stored.ClientID = acc.stored.ClientID
+			stored.ClientSecret = acc.stored.ClientSecret
+			stored.AccessToken = acc.stored.AccessToken
+		}
+		stored.stored = nil
+		accounts[i] = stored
+	}
+	return accounts, nil
+}
+
 func (ad *AccountData) Save(filepath string) error {
-	marshaled, err := toml.Marshal(ad)
+	accounts, err := ad.storedAccounts()
+	if err != nil {
+		return err
+	}
+	file := accountsFile{
+		Accounts: accounts,
+	}
+	if ad.BackendName() != BackendPlain {
+		file.Backend = ad.BackendName()
+	}
+	if ad.BackendName() == BackendEncrypted {
+		if ad.passphrase == nil {
+			ad.passphrase, err = newPassphrase()
+			if err != nil {
+				return err
+			}
+		}
+		plain, err := toml.Marshal(accountsFile{Accounts: accounts})
+		if err != nil {
+			return err
+		}
+		file.Encrypted, err = encryptAccounts(plain, ad.passphrase)
+		if err != nil {
+			return err
+		}
+		file.Accounts = nil
+	}
+	marshaled, err := toml.Marshal(file)
 	if err != nil {
 		return err
 	}
-	f, err := os.OpenFile(filepath, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	// The file is replaced when it's written, so the accounts aren't lost if
+	// tut stops halfway.
+	tmp := filepath + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
 	if err != nil {
 		return err
 	}
-	defer f.Close()
-
 	_, err = f.Write(marshaled)
-	return err
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, filepath)
+}
+
+// SetBackend moves the secrets of the accounts to backend and saves the file.
+// The secrets tut has put in a keyring are removed from it afterwards.
+func (ad *AccountData) SetBackend(filepath string, backend string) error {
+	if ad.BackendName() == backend {
+		return nil
+	}
+	var moved []Account
+	for i := range ad.Accounts {
+		if ad.Accounts[i].inKeyring() {
+			moved = append(moved, ad.Accounts[i])
+			ad.Accounts[i].stored = nil
+		}
+	}
+	ad.Backend = backend
+	if backend != BackendEncrypted {
+		ad.passphrase = nil
+	}
+	if err := ad.Save(filepath); err != nil {
+		return err
+	}
+	for _, acc := range moved {
+		if err := removeSecrets(acc); err != nil {
+			return fmt.Errorf("couldn't remove the old secrets of %s: %v", acc.Name, err)
+		}
+	}
+	return nil
+}
+
+// Find returns the index of the account named name. If two accounts have the
+// same name the host can be included, e.g. tut@fosstodon.org.
+func (ad *AccountData) Find(name string) (int, bool) {
+	useHost := strings.Contains(name, "@")
+	for i, acc := range ad.Accounts {
+		accName := acc.Name
+		if useHost {
+			host := strings.TrimPrefix(acc.Server, "https://")
+			host = strings.TrimPrefix(host, "http://")
+			accName += "@" + host
+		}
+		if accName == name {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+// Remove removes the account named name and its secrets in a keyring.
+func (ad *AccountData) Remove(filepath string, name string) error {
+	i, ok := ad.Find(name)
+	if !ok {
+		return fmt.Errorf("couldn't find an account named %s", name)
+	}
+	acc := ad.Accounts[i]
+	ad.Accounts = append(ad.Accounts[:i], ad.Accounts[i+1:]...)
+	if err := ad.Save(filepath); err != nil {
+		return err
+	}
+	return removeSecrets(acc)
+}
+
+// Rename changes the name you use to choose the account, e.g. with --user.
+func (ad *AccountData) Rename(filepath string, name string, newName string) error {
+	i, ok := ad.Find(name)
+	if !ok {
+		return fmt.Errorf("couldn't find an account named %s", name)
+	}
+	if strings.TrimSpace(newName) == "" || strings.ContainsAny(newName, " @") {
+		return errors.New("the name can't be empty or contain spaces or @")
+	}
+	if _, taken := ad.Find(newName); taken {
+		return fmt.Errorf("there's already an account named %s", newName)
+	}
+	// The secrets in a keyring are named after the account, so they're
+	// stored again under the new name and the old ones are removed.
+	old := ad.Accounts[i]
+	ad.Accounts[i].Name = newName
+	if old.inKeyring() {
+		ad.Accounts[i].stored = nil
+	}
+	if err := ad.Save(filepath); err != nil {
+		return err
+	}
+	if old.inKeyring() {
+		return removeSecrets(old)
+	}
+	return nil
+}
+
+// Reencrypt encrypts the accounts file with a new passphrase.
+func (ad *AccountData) Reencrypt(filepath string) error {
+	if ad.BackendName() != BackendEncrypted {
+		return errors.New("the accounts file isn't encrypted, set accounts-backend to encrypted in your config")
+	}
+	passphrase, err := newPassphrase()
+	if err != nil {
+		return err
+	}
+	ad.passphrase = passphrase
+	return ad.Save(filepath)
 }
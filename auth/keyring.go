@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const (
+	BackendPlain         = "plain"
+	BackendEncrypted     = "encrypted"
+	BackendSecretService = "secret-service"
+	BackendPass          = "pass"
+)
+
+// A keyring keeps the secrets of the accounts outside of the accounts file.
+// The file gets a !CMD! that looks up the secret, see GetSecret.
+type keyring interface {
+	store(key string, secret string) (string, error)
+	remove(ref string) error
+	owns(ref string) bool
+}
+
+func keyringFor(backend string) keyring {
+	switch backend {
+	case BackendSecretService:
+		return secretService{}
+	case BackendPass:
+		return pass{}
+	}
+	return nil
+}
+
+var keyrings = []keyring{secretService{}, pass{}}
+
+func runWithInput(input string, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s failed: %v %s", name, err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// secretService uses secret-tool from libsecret to store the secrets in the
+// Secret Service, e.g. GNOME Keyring or KWallet.
+type secretService struct{}
+
+const secretServicePrefix = "!CMD!secret-tool lookup service tut key "
+
+func (secretService) store(key string, secret string) (string, error) {
+	err := runWithInput(secret, "secret-tool", "store", "--label", "tut "+key, "service", "tut", "key", key)
+	return secretServicePrefix + key, err
+}
+
+func (s secretService) remove(ref string) error {
+	if !s.owns(ref) {
+		return nil
+	}
+	key := strings.TrimPrefix(ref, secretServicePrefix)
+	return runWithInput("", "secret-tool", "clear", "service", "tut", "key", key)
+}
+
+func (secretService) owns(ref string) bool {
+	return strings.HasPrefix(ref, secretServicePrefix)
+}
+
+// pass stores the secrets in the password store under tut/.
+type pass struct{}
+
+const passPrefix = "!CMD!pass show tut/"
+
+func (pass) store(key string, secret string) (string, error) {
+	err := runWithInput(secret+"\n", "pass", "insert", "--multiline", "--force", "tut/"+key)
+	return passPrefix + key, err
+}
+
+func (p pass) remove(ref string) error {
+	if !p.owns(ref) {
+		return nil
+	}
+	key := strings.TrimPrefix(ref, passPrefix)
+	return runWithInput("", "pass", "rm", "--force", "tut/"+key)
+}
+
+func (pass) owns(ref string) bool {
+	return strings.HasPrefix(ref, passPrefix)
+}
+
+// secretKey is the name of a secret of acc in a keyring, e.g.
+// fosstodon.org/tut/access-token.
+func secretKey(acc Account, name string) string {
+	host := strings.TrimPrefix(acc.Server, "https://")
+	host = strings.TrimPrefix(host, "http://")
+	return fmt.Sprintf("%s/%s/%s", host, acc.Name, name)
+}
+
+func storeSecrets(kr keyring, acc Account) (*secrets, error) {
+	var err error
+	s := &secrets{}
+	if s.ClientID, err = kr.store(secretKey(acc, "client-id"), acc.ClientID); err != nil {
+		return nil, err
+	}
+	if s.ClientSecret, err = kr.store(secretKey(acc, "client-secret"), acc.ClientSecret); err != nil {
+		return nil, err
+	}
+	if s.AccessToken, err = kr.store(secretKey(acc, "access-token"), acc.AccessToken); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// inKeyring is true if tut has put the secrets of acc in a keyring.
+func (acc Account) inKeyring() bool {
+	if acc.stored == nil {
+		return false
+	}
+	for _, kr := range keyrings {
+		if kr.owns(acc.stored.AccessToken) {
+			return true
+		}
+	}
+	return false
+}
+
+// removeSecrets removes the secrets of acc from the keyring they're in. The
+// secrets you look up with your own !CMD! are left alone.
+func removeSecrets(acc Account) error {
+	if acc.stored == nil {
+		return nil
+	}
+	for _, ref := range []string{acc.stored.ClientID, acc.stored.ClientSecret, acc.stored.AccessToken} {
+		for _, kr := range keyrings {
+			if err := kr.remove(ref); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
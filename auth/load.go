@@ -1,12 +1,20 @@
 package auth
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 
 	"github.com/RasmusLindroth/tut/util"
 )
 
-func StartAuth(newUser bool) *AccountData {
+// StartAuth loads your accounts and lets you add one if you don't have any.
+// If backend isn't empty the secrets are moved there if they're somewhere
+// else.
+func StartAuth(newUser bool, backend string) *AccountData {
 	path, exists, err := util.CheckConfig("accounts.toml")
 	if err != nil {
 		log.Fatalf("Couldn't open the account file for reading. Error: %v", err)
@@ -14,14 +22,37 @@ func StartAuth(newUser bool) *AccountData {
 	var accs *AccountData
 	if exists {
 		accs, err = GetAccounts(path)
+		if err != nil && (errors.Is(err, ErrPassphrase) || accs.BackendName() == BackendEncrypted) {
+			log.Fatalf("Couldn't decrypt the account file. Error: %v", err)
+		}
+	}
+	if err == nil && accs != nil && backend != "" && accs.BackendName() != backend {
+		if backend == BackendPlain && !confirmPlain(accs.BackendName()) {
+			log.Fatalf("Set accounts-backend to %s or leave it empty to keep your secrets there.", accs.BackendName())
+		}
+		fmt.Printf("Moving the secrets of your accounts from %s to %s\n", accs.BackendName(), backend)
+		if err := accs.SetBackend(path, backend); err != nil {
+			log.Fatalf("Couldn't move the secrets of your accounts. Error: %v", err)
+		}
 	}
 	if err != nil || accs == nil || len(accs.Accounts) == 0 || newUser {
-		if err == nil && accs != nil {
-			AddAccount(accs)
-		} else {
-			AddAccount(nil)
+		if err != nil || accs == nil {
+			accs = &AccountData{Backend: backend}
 		}
-		return StartAuth(false)
+		AddAccount(accs)
 	}
 	return accs
 }
+
+// confirmPlain asks before the secrets are moved out of from and written as
+// plain text in the accounts file.
+func confirmPlain(from string) bool {
+	fmt.Printf("accounts-backend is plain, so the secrets of your accounts will be moved from %s\n", from)
+	fmt.Print("and written as plain text in accounts.toml. Continue? [y/N]: ")
+	answer, err := util.ReadLine(bufio.NewReader(os.Stdin))
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,272 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/util"
+)
+
+const oobURI = "urn:ietf:wg:oauth:2.0:oob"
+const scopes = "read write follow"
+
+// Login is a login to an instance that is waiting for you to authorize tut in
+// the browser. With a loopback login the browser is redirected to a listener
+// on 127.0.0.1 and the login completes by itself. Otherwise you have to paste
+// the code the instance shows you.
+type Login struct {
+	Server   string
+	AuthURL  string
+	app      *mastodon.Application
+	redirect string
+	verifier string
+	state    string
+	listener net.Listener
+}
+
+// CheckServer adds https:// to server if it doesn't have a protocol and
+// checks that it's an instance.
+func CheckServer(server string) (string, error) {
+	server = strings.TrimSpace(server)
+	if !(strings.HasPrefix(server, "https://") || strings.HasPrefix(server, "http://")) {
+		server = "https://" + server
+	}
+	client := mastodon.NewClient(&mastodon.Config{
+		Server: server,
+	})
+	_, err := client.GetInstance(context.Background())
+	return server, err
+}
+
+// Headless is true if there isn't a browser on this computer that can open
+// the login page, e.g. when you're connected with SSH.
+func Headless() bool {
+	switch runtime.GOOS {
+	case "darwin", "windows":
+		return os.Getenv("SSH_CONNECTION") != ""
+	}
+	return os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// NewLogin registers tut on server. If loopback is true it listens on a random
+// port on 127.0.0.1 for the redirect, if that fails you have to paste the code.
+func NewLogin(server string, loopback bool) (*Login, error) {
+	l := &Login{
+		Server:   server,
+		redirect: oobURI,
+	}
+	var err error
+	l.verifier, err = randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	l.state, err = randomString(16)
+	if err != nil {
+		return nil, err
+	}
+	if loopback {
+		l.listener, err = net.Listen("tcp", "127.0.0.1:0")
+		if err == nil {
+			l.redirect = fmt.Sprintf("http://%s/callback", l.listener.Addr().String())
+		}
+	}
+	redirects := oobURI
+	if l.Loopback() {
+		redirects = l.redirect + "\n" + oobURI
+	}
+	l.app, err = mastodon.RegisterApp(context.Background(), &mastodon.AppConfig{
+		Server:       server,
+		ClientName:   "tut-tui",
+		Scopes:       scopes,
+		RedirectURIs: redirects,
+		Website:      "https://github.com/RasmusLindroth/tut",
+	})
+	if err != nil {
+		l.Close()
+		return nil, err
+	}
+	l.AuthURL = l.authURL()
+	return l, nil
+}
+
+// Loopback is true if the browser is redirected back to tut.
+func (l *Login) Loopback() bool {
+	return l.listener != nil
+}
+
+// UseOOB stops listening for the redirect, so the code has to be pasted.
+func (l *Login) UseOOB() {
+	l.Close()
+	l.redirect = oobURI
+	l.AuthURL = l.authURL()
+}
+
+func (l *Login) authURL() string {
+	sum := sha256.Sum256([]byte(l.verifier))
+	u, err := url.Parse(l.Server)
+	if err != nil {
+		return l.app.AuthURI
+	}
+	u.Path = path.Join(u.Path, "/oauth/authorize")
+	u.RawQuery = url.Values{
+		"client_id":             {l.app.ClientID},
+		"redirect_uri":          {l.redirect},
+		"response_type":         {"code"},
+		"scope":                 {scopes},
+		"state":                 {l.state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+	return u.String()
+}
+
+// Wait waits for the browser to be redirected back with the code and logs in
+// with it.
+func (l *Login) Wait(ctx context.Context) (*mastodon.Client, error) {
+	if !l.Loopback() {
+		return nil, errors.New("the login doesn't listen for a redirect")
+	}
+	type result struct {
+		code string
+		err  error
+	}
+	done := make(chan result, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		// Anything can connect to the port, so requests that aren't the
+		// redirect from the instance don't end the login.
+		if q.Get("state") != l.state {
+			http.Error(w, "tut: the login was redirected with the wrong state", http.StatusBadRequest)
+			return
+		}
+		var res result
+		switch {
+		case q.Get("error") != "":
+			res.err = fmt.Errorf("the login failed: %s", q.Get("error_description"))
+			if q.Get("error_description") == "" {
+				res.err = fmt.Errorf("the login failed: %s", q.Get("error"))
+			}
+		case q.Get("code") == "":
+			res.err = errors.New("the login was redirected without a code")
+		default:
+			res.code = q.Get("code")
+		}
+		if res.err != nil {
+			http.Error(w, fmt.Sprintf("tut: %v", res.err), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "You're logged in to tut. You can close this tab.")
+		}
+		select {
+		case done <- res:
+		default:
+		}
+	})
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(l.listener)
+	defer srv.Close()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-done:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return l.Exchange(ctx, res.code)
+	}
+}
+
+// Exchange logs in with the code you got when you authorized tut.
+func (l *Login) Exchange(ctx context.Context, code string) (*mastodon.Client, error) {
+	client := mastodon.NewClient(&mastodon.Config{
+		Server:       l.Server,
+		ClientID:     l.app.ClientID,
+		ClientSecret: l.app.ClientSecret,
+	})
+	u, err := url.Parse(l.Server)
+	if err != nil {
+		return nil, err
+	}
+	u.Path = path.Join(u.Path, "/oauth/token")
+	params := url.Values{
+		"client_id":     {l.app.ClientID},
+		"client_secret": {l.app.ClientSecret},
+		"grant_type":    {"authorization_code"},
+		"code":          {strings.TrimSpace(code)},
+		"redirect_uri":  {l.redirect},
+		"code_verifier": {l.verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, u.String(), strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("bad authorization: %s", resp.Status)
+	}
+	var res struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	client.Config.AccessToken = res.AccessToken
+	return client, nil
+}
+
+// Close stops listening for the redirect.
+func (l *Login) Close() {
+	if l.listener != nil {
+		l.listener.Close()
+		l.listener = nil
+	}
+}
+
+// SaveAccount adds the account client is logged in to to ad and saves it to
+// the account file.
+func SaveAccount(ad *AccountData, client *mastodon.Client) (Account, error) {
+	me, err := client.GetAccountCurrentUser(context.Background())
+	if err != nil {
+		return Account{}, err
+	}
+	acc := Account{
+		Name:         me.Username,
+		Server:       client.Config.Server,
+		ClientID:     client.Config.ClientID,
+		ClientSecret: client.Config.ClientSecret,
+		AccessToken:  client.Config.AccessToken,
+	}
+	ad.Accounts = append(ad.Accounts, acc)
+	file, _, err := util.CheckConfig("accounts.toml")
+	if err != nil {
+		return acc, err
+	}
+	return acc, ad.Save(file)
+}
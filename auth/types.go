@@ -6,8 +6,23 @@ type Account struct {
 	ClientID     string
 	ClientSecret string
 	AccessToken  string
+
+	// stored are the secrets as they're written in the accounts file, e.g. a
+	// !CMD! that looks them up. It's nil if they're written as they are.
+	stored *secrets
+}
+
+type secrets struct {
+	ClientID     string
+	ClientSecret string
+	AccessToken  string
 }
 
 type AccountData struct {
+	// Backend is where the secrets of the accounts are kept, see the
+	// Backend constants. An empty Backend is the same as BackendPlain.
+	Backend  string
 	Accounts []Account `yaml:"accounts"`
+
+	passphrase []byte
 }
@@ -39,6 +39,20 @@ type Config struct {
 	NotificationConfig Notification
 	Templates          Templates
 	Input              Input
+	Filters            []Filter
+}
+
+// Filter is a client side filter. A status matches if it matches all the
+// options that are set, and one of the values in each list.
+type Filter struct {
+	Name      string
+	Action    string
+	Accounts  []string
+	Domains   []string
+	Languages []string
+	Regex     *regexp.Regexp
+	Media     *bool
+	Timelines []FeedType
 }
 
 type LeaderAction struct {
@@ -110,6 +124,17 @@ const (
 	List
 	ListUsersIn
 	ListUsersAdd
+	Search
+	Scheduled
+	Drafts
+	Filters
+	FiltersStatus
+	DomainBlocks
+	TrendingStatuses
+	TrendingTags
+	TrendingLinks
+	Link
+	Unified
 )
 
 type NotificationToHide string
@@ -154,15 +179,20 @@ const (
 	TimelineCreationClosedCurrentPane
 )
 
+// DefaultPollInterval is how often in seconds a timeline loads newer items when
+// it can't stream, if it doesn't set poll-interval.
+const DefaultPollInterval = 60
+
 type Timeline struct {
-	ID          uint
-	FeedType    FeedType
-	Subaction   string
-	Name        string
-	Key         Key
-	Shortcut    string
-	HideBoosts  bool
-	HideReplies bool
+	ID           uint
+	FeedType     FeedType
+	Subaction    string
+	Name         string
+	Key          Key
+	Shortcut     string
+	HideBoosts   bool
+	HideReplies  bool
+	PollInterval int
 
 	Closed           bool
 	OnFocus          OnTimelineFocus
@@ -186,6 +216,7 @@ type General struct {
 	ListProportion      int
 	ContentProportion   int
 	TerminalTitle       int
+	TerminalTitleUnseen bool
 	ShowIcons           bool
 	ShowHelp            bool
 	RedrawUI            bool
@@ -194,6 +225,8 @@ type General struct {
 	LeaderActions       []LeaderAction
 	Timelines           []*Timeline
 	StickToTop          bool
+	SyncMarkers         bool
+	AccountsBackend     string
 	NotificationsToHide []NotificationToHide
 	ShowBoostedUser     bool
 	DynamicTimelineName bool
@@ -245,6 +278,9 @@ type Style struct {
 	IconColor tcell.Color
 
 	CommandText tcell.Color
+
+	DiffInsert tcell.Color
+	DiffDelete tcell.Color
 }
 
 type Media struct {
@@ -470,6 +506,16 @@ type Input struct {
 	StatusYank         Key
 	StatusToggleCW     Key
 	StatusShowFiltered Key
+	StatusFilter       Key
+	StatusBlockDomain  Key
+	StatusReport       Key
+	StatusActAs        Key
+
+	ThreadCollapse    Key
+	ThreadParent      Key
+	ThreadNextSibling Key
+
+	HistoryDiff Key
 
 	UserAvatar              Key
 	UserBlock               Key
@@ -480,14 +526,36 @@ type Input struct {
 	UserUser                Key
 	UserViewFocus           Key
 	UserYank                Key
+	UserBlockDomain         Key
+	UserReport              Key
+
+	ListOpenFeed      Key
+	ListUserList      Key
+	ListUserAdd       Key
+	ListUserDelete    Key
+	ListCreate        Key
+	ListRename        Key
+	ListDelete        Key
+	ListRepliesPolicy Key
+	ListExclusive     Key
+
+	TagOpenFeed    Key
+	TagFollow      Key
+	TrendLinkOpen  Key
+	TrendLinkToots Key
 
-	ListOpenFeed   Key
-	ListUserList   Key
-	ListUserAdd    Key
-	ListUserDelete Key
+	ScheduledEdit       Key
+	ScheduledReschedule Key
+	ScheduledDelete     Key
 
-	TagOpenFeed Key
-	TagFollow   Key
+	DraftEdit   Key
+	DraftDelete Key
+
+	FilterEdit      Key
+	FilterDelete    Key
+	FilterAddStatus Key
+
+	DomainUnblock Key
 
 	LinkOpen Key
 	LinkYank Key
@@ -501,11 +569,16 @@ type Input struct {
 	ComposeVisibility           Key
 	ComposeLanguage             Key
 	ComposePoll                 Key
+	ComposeSchedule             Key
+	ComposeAccounts             Key
 
 	MediaDelete   Key
 	MediaEditDesc Key
 	MediaAdd      Key
 
+	CrossPostToggle     Key
+	CrossPostVisibility Key
+
 	VoteVote   Key
 	VoteSelect Key
 
@@ -524,6 +597,26 @@ type Input struct {
 	PreferenceFieldsEdit   Key
 	PreferenceFieldsDelete Key
 
+	FilterTitle            Key
+	FilterContext          Key
+	FilterContextToggle    Key
+	FilterAction           Key
+	FilterExpires          Key
+	FilterKeywords         Key
+	FilterKeywordAdd       Key
+	FilterKeywordEdit      Key
+	FilterKeywordDelete    Key
+	FilterKeywordWholeWord Key
+	FilterSave             Key
+
+	ReportCategory Key
+	ReportRules    Key
+	ReportToots    Key
+	ReportToggle   Key
+	ReportComment  Key
+	ReportForward  Key
+	ReportSend     Key
+
 	EditorExit Key
 }
 
@@ -671,6 +764,19 @@ func parseTheme(cfg StyleTOML, xrdbColors map[string]string) Style {
 	} else {
 		style.CommandText = style.StatusBarText
 	}
+
+	s = NilDefaultString(cfg.DiffInsert, sp(""))
+	if len(s) > 0 {
+		style.DiffInsert = parseColor(s, "#a6e22e", xrdbColors)
+	} else {
+		style.DiffInsert = style.TextSpecial2
+	}
+	s = NilDefaultString(cfg.DiffDelete, sp(""))
+	if len(s) > 0 {
+		style.DiffDelete = parseColor(s, "#f92672", xrdbColors)
+	} else {
+		style.DiffDelete = style.WarningText
+	}
 	return style
 }
 
@@ -837,6 +943,7 @@ func parseGeneral(cfg GeneralTOML) General {
 	general.ShowHelp = NilDefaultBool(cfg.ShowHelp, def.ShowHelp)
 	general.RedrawUI = NilDefaultBool(cfg.RedrawUI, def.RedrawUI)
 	general.StickToTop = NilDefaultBool(cfg.StickToTop, def.StickToTop)
+	general.SyncMarkers = NilDefaultBool(cfg.SyncMarkers, def.SyncMarkers)
 	general.ShowBoostedUser = NilDefaultBool(cfg.ShowBoostedUser, def.ShowBoostedUser)
 	general.DynamicTimelineName = NilDefaultBool(cfg.DynamicTimelineName, def.DynamicTimelineName)
 	general.CommandsInNewPane = NilDefaultBool(cfg.CommandsInNewPane, def.CommandsInNewPane)
@@ -1000,6 +1107,27 @@ func parseGeneral(cfg GeneralTOML) General {
 			case "tag":
 				tl.FeedType = Tag
 				tl.Subaction = NilDefaultString(l.Data, sp(""))
+			case "trending":
+				switch NilDefaultString(l.Data, sp("statuses")) {
+				case "statuses":
+					tl.FeedType = TrendingStatuses
+				case "tags":
+					tl.FeedType = TrendingTags
+				case "links":
+					tl.FeedType = TrendingLinks
+				default:
+					fmt.Printf("data: %s for the trending timeline is invalid\n", *l.Data)
+					os.Exit(1)
+				}
+			case "unified":
+				tl.FeedType = Unified
+				tl.Subaction = NilDefaultString(l.Data, sp("home"))
+				switch tl.Subaction {
+				case "home", "notifications", "mentions":
+				default:
+					fmt.Printf("data: %s for the unified timeline is invalid\n", *l.Data)
+					os.Exit(1)
+				}
 			default:
 				fmt.Printf("timeline %s is invalid\n", *l.Type)
 				os.Exit(1)
@@ -1007,6 +1135,11 @@ func parseGeneral(cfg GeneralTOML) General {
 			tl.Name = NilDefaultString(l.Name, sp(""))
 			tl.HideBoosts = NilDefaultBool(l.HideBoosts, bf)
 			tl.HideReplies = NilDefaultBool(l.HideReplies, bf)
+			tl.PollInterval = NilDefaultInt(l.PollInterval, ip(DefaultPollInterval))
+			if tl.PollInterval <= 0 {
+				fmt.Printf("poll-interval must be more than 0 in timeline %s\n", *l.Type)
+				os.Exit(1)
+			}
 			tl.Closed = NilDefaultBool(l.Closed, bf)
 			tl.Shortcut = NilDefaultString(l.Shortcut, sp(""))
 			onFocus := NilDefaultString(l.OnFocus, sp(""))
@@ -1089,6 +1222,15 @@ func parseGeneral(cfg GeneralTOML) General {
 	if general.TerminalTitle < 0 || general.TerminalTitle > 3 {
 		general.TerminalTitle = 0
 	}
+	general.TerminalTitleUnseen = NilDefaultBool(cfg.TerminalTitleUnseen, def.TerminalTitleUnseen)
+
+	general.AccountsBackend = NilDefaultString(cfg.AccountsBackend, def.AccountsBackend)
+	switch general.AccountsBackend {
+	case "", "plain", "encrypted", "secret-service", "pass":
+	default:
+		fmt.Printf("accounts-backend %s is invalid\n", general.AccountsBackend)
+		os.Exit(1)
+	}
 
 	nths := []NotificationToHide{}
 	nth := cfg.NotificationsToHide
@@ -1197,6 +1339,94 @@ func parseNotifications(cfg NotificationsTOML) Notification {
 	return nc
 }
 
+func filterFeedTypes(s string) []FeedType {
+	switch s {
+	case "home":
+		return []FeedType{TimelineHome, TimelineHomeSpecial}
+	case "local":
+		return []FeedType{TimelineLocal}
+	case "federated":
+		return []FeedType{TimelineFederated}
+	case "direct":
+		return []FeedType{Conversations}
+	case "notifications":
+		return []FeedType{Notifications}
+	case "mentions":
+		return []FeedType{Mentions}
+	case "thread":
+		return []FeedType{Thread}
+	case "user":
+		return []FeedType{User}
+	case "list":
+		return []FeedType{List}
+	case "tag":
+		return []FeedType{Tag}
+	case "search":
+		return []FeedType{Search}
+	case "bookmarks", "saved":
+		return []FeedType{Saved}
+	case "favorited":
+		return []FeedType{Favorited}
+	case "trending":
+		return []FeedType{TrendingStatuses}
+	case "link":
+		return []FeedType{Link}
+	}
+	return nil
+}
+
+func parseFilters(cfg []FilterTOML) []Filter {
+	var filters []Filter
+	for i, f := range cfg {
+		filter := Filter{}
+		filter.Name = NilDefaultString(f.Name, sp(""))
+		if filter.Name == "" {
+			filter.Name = fmt.Sprintf("filter %d", i+1)
+		}
+		filter.Action = NilDefaultString(f.Action, sp("warn"))
+		if filter.Action != "warn" && filter.Action != "hide" {
+			fmt.Printf("action: %s in filter %s is invalid\n", filter.Action, filter.Name)
+			os.Exit(1)
+		}
+		if f.Accounts != nil {
+			for _, a := range *f.Accounts {
+				filter.Accounts = append(filter.Accounts, strings.ToLower(strings.TrimPrefix(a, "@")))
+			}
+		}
+		if f.Domains != nil {
+			for _, d := range *f.Domains {
+				filter.Domains = append(filter.Domains, strings.ToLower(d))
+			}
+		}
+		if f.Languages != nil {
+			for _, l := range *f.Languages {
+				filter.Languages = append(filter.Languages, strings.ToLower(l))
+			}
+		}
+		if f.Regex != nil && *f.Regex != "" {
+			re, err := regexp.Compile(*f.Regex)
+			if err != nil {
+				fmt.Printf("regex in filter %s is invalid. Error: %v\n", filter.Name, err)
+				os.Exit(1)
+			}
+			filter.Regex = re
+		}
+		filter.Media = f.Media
+		if f.Timelines != nil {
+			for _, t := range *f.Timelines {
+				ft := filterFeedTypes(t)
+				if ft == nil {
+					fmt.Printf("timeline %s in filter %s is invalid\n", t, filter.Name)
+					os.Exit(1)
+				}
+				filter.Timelines = append(filter.Timelines, ft...)
+			}
+		}
+		filters = append(filters, filter)
+	}
+	return filters
+}
+
 func parseTemplates(cfg ConfigTOML, cnfPath string, cnfDir string) Templates {
 	var tootTmpl *template.Template
 	tootTmplPath, exists, err := checkConfig("toot.tmpl", cnfPath, cnfDir)
@@ -1316,6 +1546,15 @@ func parseInput(cfg InputTOML) Input {
 	ic.StatusYank = inputOrDef("status-yank", cfg.StatusYank, def.StatusYank, false)
 	ic.StatusToggleCW = inputOrDef("status-toggle-cw", cfg.StatusToggleCW, def.StatusToggleCW, false)
 	ic.StatusShowFiltered = inputOrDef("status-show-filtered", cfg.StatusShowFiltered, def.StatusShowFiltered, false)
+	ic.StatusFilter = inputOrDef("status-filter", cfg.StatusFilter, def.StatusFilter, false)
+	ic.StatusBlockDomain = inputOrDef("status-block-domain", cfg.StatusBlockDomain, def.StatusBlockDomain, false)
+	ic.StatusReport = inputOrDef("status-report", cfg.StatusReport, def.StatusReport, false)
+	ic.StatusActAs = inputOrDef("status-act-as", cfg.StatusActAs, def.StatusActAs, false)
+	ic.ThreadCollapse = inputOrDef("thread-collapse", cfg.ThreadCollapse, def.ThreadCollapse, true)
+	ic.ThreadParent = inputOrDef("thread-parent", cfg.ThreadParent, def.ThreadParent, false)
+	ic.ThreadNextSibling = inputOrDef("thread-next-sibling", cfg.ThreadNextSibling, def.ThreadNextSibling, false)
+
+	ic.HistoryDiff = inputOrDef("history-diff", cfg.HistoryDiff, def.HistoryDiff, true)
 
 	ic.UserAvatar = inputOrDef("user-avatar", cfg.UserAvatar, def.UserAvatar, false)
 	ic.UserBlock = inputOrDef("user-block", cfg.UserBlock, def.UserBlock, true)
@@ -1326,14 +1565,35 @@ func parseInput(cfg InputTOML) Input {
 	ic.UserUser = inputOrDef("user-user", cfg.UserUser, def.UserUser, false)
 	ic.UserViewFocus = inputOrDef("user-view-focus", cfg.UserViewFocus, def.UserViewFocus, false)
 	ic.UserYank = inputOrDef("user-yank", cfg.UserYank, def.UserYank, false)
+	ic.UserBlockDomain = inputOrDef("user-block-domain", cfg.UserBlockDomain, def.UserBlockDomain, true)
+	ic.UserReport = inputOrDef("user-report", cfg.UserReport, def.UserReport, false)
 
 	ic.ListOpenFeed = inputOrDef("list-open-feed", cfg.ListOpenFeed, def.ListOpenFeed, false)
 	ic.ListUserList = inputOrDef("list-user-list", cfg.ListUserList, def.ListUserList, false)
 	ic.ListUserAdd = inputOrDef("list-user-add", cfg.ListUserAdd, def.ListUserAdd, false)
 	ic.ListUserDelete = inputOrDef("list-user-delete", cfg.ListUserDelete, def.ListUserDelete, false)
+	ic.ListCreate = inputOrDef("list-create", cfg.ListCreate, def.ListCreate, false)
+	ic.ListRename = inputOrDef("list-rename", cfg.ListRename, def.ListRename, false)
+	ic.ListDelete = inputOrDef("list-delete", cfg.ListDelete, def.ListDelete, false)
+	ic.ListRepliesPolicy = inputOrDef("list-replies-policy", cfg.ListRepliesPolicy, def.ListRepliesPolicy, false)
+	ic.ListExclusive = inputOrDef("list-exclusive", cfg.ListExclusive, def.ListExclusive, true)
 
 	ic.TagOpenFeed = inputOrDef("tag-open-feed", cfg.TagOpenFeed, def.TagOpenFeed, false)
 	ic.TagFollow = inputOrDef("tag-follow", cfg.TagFollow, def.TagFollow, true)
+	ic.TrendLinkOpen = inputOrDef("trend-link-open", cfg.TrendLinkOpen, def.TrendLinkOpen, false)
+	ic.TrendLinkToots = inputOrDef("trend-link-toots", cfg.TrendLinkToots, def.TrendLinkToots, false)
+
+	ic.ScheduledEdit = inputOrDef("scheduled-edit", cfg.ScheduledEdit, def.ScheduledEdit, false)
+	ic.ScheduledReschedule = inputOrDef("scheduled-reschedule", cfg.ScheduledReschedule, def.ScheduledReschedule, false)
+	ic.ScheduledDelete = inputOrDef("scheduled-delete", cfg.ScheduledDelete, def.ScheduledDelete, false)
+
+	ic.DraftEdit = inputOrDef("draft-edit", cfg.DraftEdit, def.DraftEdit, false)
+	ic.DraftDelete = inputOrDef("draft-delete", cfg.DraftDelete, def.DraftDelete, false)
+	ic.FilterEdit = inputOrDef("filter-edit", cfg.FilterEdit, def.FilterEdit, false)
+	ic.FilterDelete = inputOrDef("filter-delete", cfg.FilterDelete, def.FilterDelete, false)
+	ic.FilterAddStatus = inputOrDef("filter-add-status", cfg.FilterAddStatus, def.FilterAddStatus, false)
+	ic.DomainUnblock = inputOrDef("domain-unblock", cfg.DomainUnblock, def.DomainUnblock, false)
+
 	ic.LinkOpen = inputOrDef("link-open", cfg.LinkOpen, def.LinkOpen, false)
 	ic.LinkYank = inputOrDef("link-yank", cfg.LinkYank, def.LinkYank, false)
 
@@ -1346,11 +1606,16 @@ func parseInput(cfg InputTOML) Input {
 	ic.ComposeVisibility = inputOrDef("compose-visibility", cfg.ComposeVisibility, def.ComposeVisibility, false)
 	ic.ComposeLanguage = inputOrDef("compose-language", cfg.ComposeLanguage, def.ComposeLanguage, false)
 	ic.ComposePoll = inputOrDef("compose-poll", cfg.ComposePoll, def.ComposePoll, false)
+	ic.ComposeSchedule = inputOrDef("compose-schedule", cfg.ComposeSchedule, def.ComposeSchedule, false)
+	ic.ComposeAccounts = inputOrDef("compose-accounts", cfg.ComposeAccounts, def.ComposeAccounts, false)
 
 	ic.MediaDelete = inputOrDef("media-delete", cfg.MediaDelete, def.MediaDelete, false)
 	ic.MediaEditDesc = inputOrDef("media-edit-desc", cfg.MediaEditDesc, def.MediaEditDesc, false)
 	ic.MediaAdd = inputOrDef("media-add", cfg.MediaAdd, def.MediaAdd, false)
 
+	ic.CrossPostToggle = inputOrDef("cross-post-toggle", cfg.CrossPostToggle, def.CrossPostToggle, false)
+	ic.CrossPostVisibility = inputOrDef("cross-post-visibility", cfg.CrossPostVisibility, def.CrossPostVisibility, false)
+
 	ic.VoteVote = inputOrDef("vote-vote", cfg.VoteVote, def.VoteVote, false)
 	ic.VoteSelect = inputOrDef("vote-select", cfg.VoteSelect, def.VoteSelect, false)
 
@@ -1368,6 +1633,24 @@ func parseInput(cfg InputTOML) Input {
 	ic.PreferenceFieldsAdd = inputOrDef("preference-fields-add", cfg.PreferenceFieldsAdd, def.PreferenceFieldsAdd, false)
 	ic.PreferenceFieldsEdit = inputOrDef("preference-fields-edit", cfg.PreferenceFieldsEdit, def.PreferenceFieldsEdit, false)
 	ic.PreferenceFieldsDelete = inputOrDef("preference-fields-delete", cfg.PreferenceFieldsDelete, def.PreferenceFieldsDelete, false)
+	ic.FilterTitle = inputOrDef("filter-title", cfg.FilterTitle, def.FilterTitle, false)
+	ic.FilterContext = inputOrDef("filter-context", cfg.FilterContext, def.FilterContext, false)
+	ic.FilterContextToggle = inputOrDef("filter-context-toggle", cfg.FilterContextToggle, def.FilterContextToggle, false)
+	ic.FilterAction = inputOrDef("filter-action", cfg.FilterAction, def.FilterAction, false)
+	ic.FilterExpires = inputOrDef("filter-expires", cfg.FilterExpires, def.FilterExpires, false)
+	ic.FilterKeywords = inputOrDef("filter-keywords", cfg.FilterKeywords, def.FilterKeywords, false)
+	ic.FilterKeywordAdd = inputOrDef("filter-keyword-add", cfg.FilterKeywordAdd, def.FilterKeywordAdd, false)
+	ic.FilterKeywordEdit = inputOrDef("filter-keyword-edit", cfg.FilterKeywordEdit, def.FilterKeywordEdit, false)
+	ic.FilterKeywordDelete = inputOrDef("filter-keyword-delete", cfg.FilterKeywordDelete, def.FilterKeywordDelete, false)
+	ic.FilterKeywordWholeWord = inputOrDef("filter-keyword-whole-word", cfg.FilterKeywordWholeWord, def.FilterKeywordWholeWord, false)
+	ic.FilterSave = inputOrDef("filter-save", cfg.FilterSave, def.FilterSave, false)
+	ic.ReportCategory = inputOrDef("report-category", cfg.ReportCategory, def.ReportCategory, false)
+	ic.ReportRules = inputOrDef("report-rules", cfg.ReportRules, def.ReportRules, false)
+	ic.ReportToots = inputOrDef("report-toots", cfg.ReportToots, def.ReportToots, false)
+	ic.ReportToggle = inputOrDef("report-toggle", cfg.ReportToggle, def.ReportToggle, false)
+	ic.ReportComment = inputOrDef("report-comment", cfg.ReportComment, def.ReportComment, false)
+	ic.ReportForward = inputOrDef("report-forward", cfg.ReportForward, def.ReportForward, true)
+	ic.ReportSend = inputOrDef("report-send", cfg.ReportSend, def.ReportSend, false)
 
 	ic.EditorExit = inputOrDef("editor-exit", cfg.EditorExit, def.EditorExit, false)
 	return ic
@@ -1400,6 +1683,7 @@ func parseConfig(filepath string, cnfPath string, cnfDir string) (Config, error)
 	conf.NotificationConfig = parseNotifications(cnf.NotificationConfig)
 	conf.Templates = parseTemplates(cnf, cnfPath, cnfDir)
 	conf.Input = parseInput(cnf.Input)
+	conf.Filters = parseFilters(cnf.Filters)
 
 	return conf, nil
 }
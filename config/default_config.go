@@ -91,6 +91,24 @@ show-help=true
 # default=false
 stick-to-top=false
 
+# Save how far you have read in your home and notifications timelines on your
+# instance, so other apps can continue from there and tut starts where you
+# stopped. A line shows where you stopped reading and the title shows how many
+# posts you haven't read.
+# default=true
+sync-markers=true
+
+# Where the secrets of your accounts are kept. With plain they're written as
+# they are in accounts.toml. With encrypted the file is encrypted with a
+# passphrase that tut asks for when it starts. secret-service and pass keep them
+# in your keyring or in pass and the file only tells tut where to find them.
+# Leave it empty to keep them where they are, new files are plain. Tut moves the
+# secrets the next time it starts when you change this, and asks before it
+# writes them as plain text.
+# valid: "", plain, encrypted, secret-service, pass
+# default=""
+accounts-backend=""
+
 # Display the username of the person being boosted instead of the person that
 # boosted.
 # default=false
@@ -113,6 +131,11 @@ dynamic-timeline-name=true
 # default=0
 terminal-title=0
 
+# Show how many new posts you haven't seen in all panes at the start of the
+# terminal title. It needs terminal-title to be 1 or 2.
+# default=false
+terminal-title-unseen=false
+
 # If you don't want the whole UI to update, and only update the text content you
 # can disable this. This will lead to some artifacts being left on the screen
 # when emojis are present.
@@ -157,12 +180,15 @@ leader-timeout=1000
 
 # The type of the timeline
 # valid: home, direct, local, federated, bookmarks, saved, favorited, notifications,
-# lists, mentions, tag
+# lists, mentions, tag, trending, unified
 # default=""
 # type=""
 
 # Used for the tag type, so here you set the tag. If you have multiple you
-# separate them with a space.
+# separate them with a space. For the trending type you set what's trending,
+# statuses, tags or links. It defaults to statuses. The unified type merges
+# the timelines of all accounts you are logged in to, set which one to merge,
+# home, notifications or mentions. It defaults to home.
 # default=""
 # data=""
 
@@ -188,6 +214,12 @@ leader-timeout=1000
 # default="false"
 # hide-replies="false"
 
+# How often to load new items in seconds if the timeline can't use streaming,
+# e.g. if the server doesn't support it. tut switches back to streaming when
+# it works again. It must be more than 0.
+# default=60
+# poll-interval=60
+
 # Don't open this timeline when you start tut. Use your keys or shortcut to open
 # it.
 # default="false"
@@ -380,6 +412,62 @@ poll=false
 # default=false
 posts=false
 
+# Client side filters. Unlike the filters on your server they can match
+# accounts, domains, languages, regular expressions and media. You can have
+# multiple of them.
+# --- START OF EXAMPLE ---
+# [[filter]]
+# name="No spoilers"
+# action="warn"
+# regex="(?i)spoiler|finale"
+# timelines=["home", "local", "federated"]
+#
+# [[filter]]
+# name="Loud instance"
+# action="hide"
+# domains=["example.com"]
+# --- END OF EXAMPLE ---
+
+# [[filter]]
+# The name of the filter, it's shown when a toot is filtered. If it's empty
+# the filter is named after its position, e.g. filter 2.
+# default=""
+# name=""
+
+# What to do with matching toots. warn shows that the toot is filtered and
+# lets you view it, hide removes it from the timeline.
+# valid: warn, hide
+# default="warn"
+# action="warn"
+
+# Match toots from these accounts, e.g. user@example.com, or just user for
+# accounts on your instance.
+# default=[]
+# accounts=[]
+
+# Match toots from accounts on these domains. Subdomains match as well.
+# default=[]
+# domains=[]
+
+# Match toots in these languages, e.g. en or sv.
+# default=[]
+# languages=[]
+
+# Match toots where the text or content warning matches this regular
+# expression. Use (?i) at the start to ignore case.
+# default=""
+# regex=""
+
+# Set to true to match toots with media and false to match toots without media.
+# Leave it out to match both.
+# media=true
+
+# The timelines to use the filter in. If it's empty it's used everywhere.
+# valid: home, local, federated, direct, notifications, mentions, thread, user,
+# list, tag, search, bookmarks, favorited, trending, link
+# default=[]
+# timelines=[]
+
 [open-custom]
 # --- START OF EXAMPLE ---
 # [[open-custom.programs]]
@@ -583,6 +671,14 @@ timeline-name-background=""
 # default=""
 timeline-name-text=""
 
+# The color of added words when you compare versions of an edited toot.
+# default=""
+diff-insert=""
+
+# The color of removed words when you compare versions of an edited toot.
+# default=""
+diff-delete=""
+
 [input]
 # In this section you set the keys to be used in tut.
 # 		
@@ -864,6 +960,85 @@ hint="Press [Z] to view filtered toot"
 # default=["z", "Z"]
 keys=["z","Z"]
 
+[input.status-filter]
+# Add the toot to a filter
+
+# default="F[i]lter"
+hint="F[i]lter"
+
+# default=["i", "I"]
+keys=["i","I"]
+
+[input.status-block-domain]
+# Block the domain of the toot's author
+
+# default="Block domain [x]"
+hint="Block domain [x]"
+
+# default=["x", "X"]
+keys=["x","X"]
+
+[input.status-report]
+# Report the toot to the moderators
+
+# default="Report [!]"
+hint="Report [!]"
+
+# default=["!"]
+keys=["!"]
+
+[input.status-act-as]
+# Favorite, boost, save or reply to the toot as another account you are logged
+# in to
+
+# default="Act as [@]"
+hint="Act as [@]"
+
+# default=["@"]
+keys=["@"]
+
+[input.thread-collapse]
+# Hide or show the replies to a toot in a thread
+
+# default="Collapse [-]"
+hint="Collapse [-]"
+
+# default="Expand [-]"
+hint-alt="Expand [-]"
+
+# default=["-"]
+keys=["-"]
+
+[input.thread-parent]
+# Go to the toot that a toot in a thread replies to
+
+# default="Parent [<]"
+hint="Parent [<]"
+
+# default=["<"]
+keys=["<"]
+
+[input.thread-next-sibling]
+# Go to the next reply to the same toot in a thread
+
+# default="Next reply [>]"
+hint="Next reply [>]"
+
+# default=[">"]
+keys=[">"]
+
+[input.history-diff]
+# Show the changes between a version of an edited toot and the version before it
+
+# default="Show [d]iff"
+hint="Show [d]iff"
+
+# default="Hide [d]iff"
+hint-alt="Hide [d]iff"
+
+# default=["d"]
+keys=["d"]
+
 [input.user-avatar]
 # View avatar
 
@@ -957,6 +1132,27 @@ hint="[Y]ank"
 # default=["y", "Y"]
 keys=["y","Y"]
 
+[input.user-block-domain]
+# Block or unblock the user's domain
+
+# default="Block domain [x]"
+hint="Block domain [x]"
+
+# default="Unblock domain [x]"
+hint-alt="Unblock domain [x]"
+
+# default=["x", "X"]
+keys=["x","X"]
+
+[input.user-report]
+# Report the user to the moderators
+
+# default="Report [!]"
+hint="Report [!]"
+
+# default=["!"]
+keys=["!"]
+
 [input.list-open-feed]
 # Open list
 
@@ -993,6 +1189,54 @@ hint="[D]elete"
 # default=["d", "D"]
 keys=["d","D"]
 
+[input.list-create]
+# Create a new list
+
+# default="[N]ew"
+hint="[N]ew"
+
+# default=["n", "N"]
+keys=["n","N"]
+
+[input.list-rename]
+# Rename list
+
+# default="[R]ename"
+hint="[R]ename"
+
+# default=["r", "R"]
+keys=["r","R"]
+
+[input.list-delete]
+# Delete list
+
+# default="[D]elete"
+hint="[D]elete"
+
+# default=["d", "D"]
+keys=["d","D"]
+
+[input.list-replies-policy]
+# Cycle which replies are shown in the list: list, followed or none
+
+# default="Re[p]lies"
+hint="Re[p]lies"
+
+# default=["p", "P"]
+keys=["p","P"]
+
+[input.list-exclusive]
+# Toggle if posts in the list are hidden from the home timeline
+
+# default="[E]xclusive"
+hint="[E]xclusive"
+
+# default="Not [E]xclusive"
+hint-alt="Not [E]xclusive"
+
+# default=["e", "E"]
+keys=["e","E"]
+
 [input.link-open]
 # Open URL
 
@@ -1032,6 +1276,105 @@ hint-alt="Un[F]ollow"
 # default=["f", "F"]
 keys=["f","F"]
 
+[input.trend-link-open]
+# Open a trending link in your browser
+
+# default="[O]pen"
+hint="[O]pen"
+
+# default=["o", "O"]
+keys=["o","O"]
+
+[input.trend-link-toots]
+# Show the toots that shared a trending link
+
+# default="[T]oots"
+hint="[T]oots"
+
+# default=["t", "T"]
+keys=["t","T"]
+
+[input.scheduled-edit]
+# Edit a scheduled toot
+
+# default="[E]dit"
+hint="[E]dit"
+
+# default=["e", "E"]
+keys=["e","E"]
+
+[input.scheduled-reschedule]
+# Change when a scheduled toot will be posted
+
+# default="[R]eschedule"
+hint="[R]eschedule"
+
+# default=["r", "R"]
+keys=["r","R"]
+
+[input.scheduled-delete]
+# Cancel a scheduled toot
+
+# default="[D]elete"
+hint="[D]elete"
+
+# default=["d", "D"]
+keys=["d","D"]
+
+[input.draft-edit]
+# Continue writing a draft
+
+# default="[E]dit"
+hint="[E]dit"
+
+# default=["e", "E"]
+keys=["e","E"]
+
+[input.draft-delete]
+# Delete a draft
+
+# default="[D]elete"
+hint="[D]elete"
+
+# default=["d", "D"]
+keys=["d","D"]
+
+[input.filter-edit]
+# Edit a filter
+
+# default="[E]dit"
+hint="[E]dit"
+
+# default=["e", "E"]
+keys=["e","E"]
+
+[input.filter-delete]
+# Delete a filter
+
+# default="[D]elete"
+hint="[D]elete"
+
+# default=["d", "D"]
+keys=["d","D"]
+
+[input.filter-add-status]
+# Add the toot to the selected filter
+
+# default="[A]dd toot"
+hint="[A]dd toot"
+
+# default=["a", "A"]
+keys=["a","A"]
+
+[input.domain-unblock]
+# Unblock the domain
+
+# default="[U]nblock"
+hint="[U]nblock"
+
+# default=["u", "U"]
+keys=["u","U"]
+
 [input.compose-edit-cw]
 # Edit content warning text on new toot
 
@@ -1113,6 +1456,24 @@ hint="P[O]ll"
 # default=["o", "O"]
 keys=["o","O"]
 
+[input.compose-schedule]
+# Set when the toot should be posted, e.g. +2h or 2006-01-02 15:04
+
+# default="[S]chedule"
+hint="[S]chedule"
+
+# default=["s", "S"]
+keys=["s","S"]
+
+[input.compose-accounts]
+# Choose the accounts to post the toot to, if you are logged in to more than one
+
+# default="[A]ccounts"
+hint="[A]ccounts"
+
+# default=["a", "A"]
+keys=["a","A"]
+
 [input.media-delete]
 # Delete media file
 
@@ -1140,6 +1501,24 @@ hint="[A]dd"
 # default=["a", "A"]
 keys=["a","A"]
 
+[input.cross-post-toggle]
+# Post or don't post to the selected account
+
+# default="[T]oggle"
+hint="[T]oggle"
+
+# default=["t", "T"]
+keys=["t","T"]
+
+[input.cross-post-visibility]
+# Change the visibility of the toot on the selected account
+
+# default="[V]isibility"
+hint="[V]isibility"
+
+# default=["v", "V"]
+keys=["v","V"]
+
 [input.vote-vote]
 # Vote on poll
 
@@ -1275,6 +1654,171 @@ hint="[D]elete"
 # default=["d", "D"]
 keys=["d","D"]
 
+[input.filter-title]
+# Edit the title of the filter
+
+# default="[T]itle"
+hint="[T]itle"
+
+# default=["t", "T"]
+keys=["t","T"]
+
+[input.filter-context]
+# Focus the contexts where the filter is used
+
+# default="[C]ontext"
+hint="[C]ontext"
+
+# default=["c", "C"]
+keys=["c","C"]
+
+[input.filter-context-toggle]
+# Turn the selected context on or off
+
+# default="[T]oggle"
+hint="[T]oggle"
+
+# default=["t", "T"]
+keys=["t","T"]
+
+[input.filter-action]
+# Select if matches are hidden or shown with a warning
+
+# default="[A]ction"
+hint="[A]ction"
+
+# default=["a", "A"]
+keys=["a","A"]
+
+[input.filter-expires]
+# Set when the filter expires
+
+# default="E[x]pires"
+hint="E[x]pires"
+
+# default=["x", "X"]
+keys=["x","X"]
+
+[input.filter-keywords]
+# Focus the keywords of the filter
+
+# default="[K]eywords"
+hint="[K]eywords"
+
+# default=["k", "K"]
+keys=["k","K"]
+
+[input.filter-keyword-add]
+# Add a keyword
+
+# default="[A]dd"
+hint="[A]dd"
+
+# default=["a", "A"]
+keys=["a","A"]
+
+[input.filter-keyword-edit]
+# Edit the selected keyword
+
+# default="[E]dit"
+hint="[E]dit"
+
+# default=["e", "E"]
+keys=["e","E"]
+
+[input.filter-keyword-delete]
+# Delete the selected keyword
+
+# default="[D]elete"
+hint="[D]elete"
+
+# default=["d", "D"]
+keys=["d","D"]
+
+[input.filter-keyword-whole-word]
+# Toggle if the selected keyword only matches whole words
+
+# default="[W]hole word"
+hint="[W]hole word"
+
+# default=["w", "W"]
+keys=["w","W"]
+
+[input.filter-save]
+# Save the filter
+
+# default="[S]ave"
+hint="[S]ave"
+
+# default=["s", "S"]
+keys=["s","S"]
+
+[input.report-category]
+# Select the category of the report
+
+# default="[C]ategory"
+hint="[C]ategory"
+
+# default=["c", "C"]
+keys=["c","C"]
+
+[input.report-rules]
+# Select the rules that are broken, only for the violation category
+
+# default="[R]ules"
+hint="[R]ules"
+
+# default=["r", "R"]
+keys=["r","R"]
+
+[input.report-toots]
+# Select the toots to include in the report
+
+# default="[T]oots"
+hint="[T]oots"
+
+# default=["t", "T"]
+keys=["t","T"]
+
+[input.report-toggle]
+# Toggle the selected rule or toot
+
+# default="[T]oggle"
+hint="[T]oggle"
+
+# default=["t", "T"]
+keys=["t","T"]
+
+[input.report-comment]
+# Add a comment to the report
+
+# default="Co[m]ment"
+hint="Co[m]ment"
+
+# default=["m", "M"]
+keys=["m","M"]
+
+[input.report-forward]
+# Toggle if the report is forwarded to the instance of the user
+
+# default="[F]orward"
+hint="[F]orward"
+
+# default="Don't [f]orward"
+hint-alt="Don't [f]orward"
+
+# default=["f", "F"]
+keys=["f","F"]
+
+[input.report-send]
+# Send the report
+
+# default="[S]end"
+hint="[S]end"
+
+# default=["s", "S"]
+keys=["s","S"]
+
 [input.editor-exit]
 # Exit the editor
 
@@ -8,6 +8,18 @@ type ConfigTOML struct {
 	OpenCustom         OpenCustomTOML    `toml:"open-custom"`
 	NotificationConfig NotificationsTOML `toml:"desktop-notification"`
 	Input              InputTOML         `toml:"input"`
+	Filters            []FilterTOML      `toml:"filter"`
+}
+
+type FilterTOML struct {
+	Name      *string   `toml:"name"`
+	Action    *string   `toml:"action"`
+	Accounts  *[]string `toml:"accounts"`
+	Domains   *[]string `toml:"domains"`
+	Languages *[]string `toml:"languages"`
+	Regex     *string   `toml:"regex"`
+	Media     *bool     `toml:"media"`
+	Timelines *[]string `toml:"timelines"`
 }
 
 type GeneralTOML struct {
@@ -26,6 +38,7 @@ type GeneralTOML struct {
 	ListProportion      *int                `toml:"list-proportion"`
 	ContentProportion   *int                `toml:"content-proportion"`
 	TerminalTitle       *int                `toml:"terminal-title"`
+	TerminalTitleUnseen *bool               `toml:"terminal-title-unseen"`
 	ShowIcons           *bool               `toml:"show-icons"`
 	ShowHelp            *bool               `toml:"show-help"`
 	RedrawUI            *bool               `toml:"redraw-ui"`
@@ -34,6 +47,8 @@ type GeneralTOML struct {
 	Timelines           *[]TimelineTOML     `toml:"timelines"`
 	LeaderActions       *[]LeaderActionTOML `toml:"leader-actions"`
 	StickToTop          *bool               `toml:"stick-to-top"`
+	SyncMarkers         *bool               `toml:"sync-markers"`
+	AccountsBackend     *string             `toml:"accounts-backend"`
 	NotificationsToHide *[]string           `toml:"notifications-to-hide"`
 	ShowBoostedUser     *bool               `toml:"show-boosted-user"`
 	DynamicTimelineName *bool               `toml:"dynamic-timeline-name"`
@@ -41,14 +56,15 @@ type GeneralTOML struct {
 }
 
 type TimelineTOML struct {
-	Name        *string   `toml:"name"`
-	Type        *string   `toml:"type"`
-	Data        *string   `toml:"data"`
-	Keys        *[]string `toml:"keys"`
-	SpecialKeys *[]string `toml:"special-keys"`
-	Shortcut    *string   `toml:"shortcut"`
-	HideBoosts  *bool     `toml:"hide-boosts"`
-	HideReplies *bool     `toml:"hide-replies"`
+	Name         *string   `toml:"name"`
+	Type         *string   `toml:"type"`
+	Data         *string   `toml:"data"`
+	Keys         *[]string `toml:"keys"`
+	SpecialKeys  *[]string `toml:"special-keys"`
+	Shortcut     *string   `toml:"shortcut"`
+	HideBoosts   *bool     `toml:"hide-boosts"`
+	HideReplies  *bool     `toml:"hide-replies"`
+	PollInterval *int      `toml:"poll-interval"`
 
 	Closed           *bool   `toml:"closed"`
 	OnCreationClosed *string `toml:"on-creation-closed"`
@@ -108,6 +124,9 @@ type StyleTOML struct {
 	IconColor *string `toml:"icon-color"`
 
 	CommandText *string `toml:"command-text"`
+
+	DiffInsert *string `toml:"diff-insert"`
+	DiffDelete *string `toml:"diff-delete"`
 }
 
 type ViewerTOML struct {
@@ -200,6 +219,16 @@ type InputTOML struct {
 	StatusYank         *KeyHintTOML `toml:"status-yank"`
 	StatusToggleCW     *KeyHintTOML `toml:"status-toggle-cw"`
 	StatusShowFiltered *KeyHintTOML `toml:"status-show-filtered"`
+	StatusFilter       *KeyHintTOML `toml:"status-filter"`
+	StatusBlockDomain  *KeyHintTOML `toml:"status-block-domain"`
+	StatusReport       *KeyHintTOML `toml:"status-report"`
+	StatusActAs        *KeyHintTOML `toml:"status-act-as"`
+
+	ThreadCollapse    *KeyHintTOML `toml:"thread-collapse"`
+	ThreadParent      *KeyHintTOML `toml:"thread-parent"`
+	ThreadNextSibling *KeyHintTOML `toml:"thread-next-sibling"`
+
+	HistoryDiff *KeyHintTOML `toml:"history-diff"`
 
 	UserAvatar              *KeyHintTOML `toml:"user-avatar"`
 	UserBlock               *KeyHintTOML `toml:"user-block"`
@@ -210,14 +239,36 @@ type InputTOML struct {
 	UserUser                *KeyHintTOML `toml:"user-user"`
 	UserViewFocus           *KeyHintTOML `toml:"user-view-focus"`
 	UserYank                *KeyHintTOML `toml:"user-yank"`
+	UserBlockDomain         *KeyHintTOML `toml:"user-block-domain"`
+	UserReport              *KeyHintTOML `toml:"user-report"`
 
-	ListOpenFeed   *KeyHintTOML `toml:"list-open-feed"`
-	ListUserList   *KeyHintTOML `toml:"list-user-list"`
-	ListUserAdd    *KeyHintTOML `toml:"list-user-add"`
-	ListUserDelete *KeyHintTOML `toml:"list-user-delete"`
+	ListOpenFeed      *KeyHintTOML `toml:"list-open-feed"`
+	ListUserList      *KeyHintTOML `toml:"list-user-list"`
+	ListUserAdd       *KeyHintTOML `toml:"list-user-add"`
+	ListUserDelete    *KeyHintTOML `toml:"list-user-delete"`
+	ListCreate        *KeyHintTOML `toml:"list-create"`
+	ListRename        *KeyHintTOML `toml:"list-rename"`
+	ListDelete        *KeyHintTOML `toml:"list-delete"`
+	ListRepliesPolicy *KeyHintTOML `toml:"list-replies-policy"`
+	ListExclusive     *KeyHintTOML `toml:"list-exclusive"`
 
-	TagOpenFeed *KeyHintTOML `toml:"tag-open-feed"`
-	TagFollow   *KeyHintTOML `toml:"tag-follow"`
+	TagOpenFeed    *KeyHintTOML `toml:"tag-open-feed"`
+	TagFollow      *KeyHintTOML `toml:"tag-follow"`
+	TrendLinkOpen  *KeyHintTOML `toml:"trend-link-open"`
+	TrendLinkToots *KeyHintTOML `toml:"trend-link-toots"`
+
+	ScheduledEdit       *KeyHintTOML `toml:"scheduled-edit"`
+	ScheduledReschedule *KeyHintTOML `toml:"scheduled-reschedule"`
+	ScheduledDelete     *KeyHintTOML `toml:"scheduled-delete"`
+
+	DraftEdit   *KeyHintTOML `toml:"draft-edit"`
+	DraftDelete *KeyHintTOML `toml:"draft-delete"`
+
+	FilterEdit      *KeyHintTOML `toml:"filter-edit"`
+	FilterDelete    *KeyHintTOML `toml:"filter-delete"`
+	FilterAddStatus *KeyHintTOML `toml:"filter-add-status"`
+
+	DomainUnblock *KeyHintTOML `toml:"domain-unblock"`
 
 	LinkOpen *KeyHintTOML `toml:"link-open"`
 	LinkYank *KeyHintTOML `toml:"link-yank"`
@@ -231,11 +282,16 @@ type InputTOML struct {
 	ComposeVisibility           *KeyHintTOML `toml:"compose-visibility"`
 	ComposeLanguage             *KeyHintTOML `toml:"compose-language"`
 	ComposePoll                 *KeyHintTOML `toml:"compose-poll"`
+	ComposeSchedule             *KeyHintTOML `toml:"compose-schedule"`
+	ComposeAccounts             *KeyHintTOML `toml:"compose-accounts"`
 
 	MediaDelete   *KeyHintTOML `toml:"media-delete"`
 	MediaEditDesc *KeyHintTOML `toml:"media-edit-desc"`
 	MediaAdd      *KeyHintTOML `toml:"media-add"`
 
+	CrossPostToggle     *KeyHintTOML `toml:"cross-post-toggle"`
+	CrossPostVisibility *KeyHintTOML `toml:"cross-post-visibility"`
+
 	VoteVote   *KeyHintTOML `toml:"vote-vote"`
 	VoteSelect *KeyHintTOML `toml:"vote-select"`
 
@@ -254,5 +310,25 @@ type InputTOML struct {
 	PreferenceFieldsEdit   *KeyHintTOML `toml:"preference-fields-edit"`
 	PreferenceFieldsDelete *KeyHintTOML `toml:"preference-fields-delete"`
 
+	FilterTitle            *KeyHintTOML `toml:"filter-title"`
+	FilterContext          *KeyHintTOML `toml:"filter-context"`
+	FilterContextToggle    *KeyHintTOML `toml:"filter-context-toggle"`
+	FilterAction           *KeyHintTOML `toml:"filter-action"`
+	FilterExpires          *KeyHintTOML `toml:"filter-expires"`
+	FilterKeywords         *KeyHintTOML `toml:"filter-keywords"`
+	FilterKeywordAdd       *KeyHintTOML `toml:"filter-keyword-add"`
+	FilterKeywordEdit      *KeyHintTOML `toml:"filter-keyword-edit"`
+	FilterKeywordDelete    *KeyHintTOML `toml:"filter-keyword-delete"`
+	FilterKeywordWholeWord *KeyHintTOML `toml:"filter-keyword-whole-word"`
+	FilterSave             *KeyHintTOML `toml:"filter-save"`
+
+	ReportCategory *KeyHintTOML `toml:"report-category"`
+	ReportRules    *KeyHintTOML `toml:"report-rules"`
+	ReportToots    *KeyHintTOML `toml:"report-toots"`
+	ReportToggle   *KeyHintTOML `toml:"report-toggle"`
+	ReportComment  *KeyHintTOML `toml:"report-comment"`
+	ReportForward  *KeyHintTOML `toml:"report-forward"`
+	ReportSend     *KeyHintTOML `toml:"report-send"`
+
 	EditorExit *KeyHintTOML `toml:"editor-exit"`
 }
@@ -33,6 +33,8 @@ var ConfigDefault = ConfigTOML{
 		ShowHelp:            bt,
 		RedrawUI:            bt,
 		StickToTop:          bf,
+		SyncMarkers:         bt,
+		AccountsBackend:     sp(""),
 		ShowBoostedUser:     bf,
 		DynamicTimelineName: bt,
 		CommandsInNewPane:   bt,
@@ -41,6 +43,7 @@ var ConfigDefault = ConfigTOML{
 		ListProportion:      ip(1),
 		ContentProportion:   ip(2),
 		TerminalTitle:       ip(0),
+		TerminalTitleUnseen: bf,
 		LeaderKey:           sp(""),
 		LeaderTimeout:       ip64(1000),
 		NotificationsToHide: &[]string{},
@@ -88,6 +91,8 @@ var ConfigDefault = ConfigTOML{
 		ButtonColorTwo:                 sp("#272822"),
 		TimelineNameBackground:         sp("#272822"),
 		TimelineNameText:               sp("#808080"),
+		DiffInsert:                     sp("#a6e22e"),
+		DiffDelete:                     sp("#f92672"),
 	},
 	Media: MediaTOML{
 		DeleteTmpFiles: bt,
@@ -256,6 +261,40 @@ var ConfigDefault = ConfigTOML{
 			Hint: sp("Press [Z] to view filtered toot"),
 			Keys: &[]string{"z", "Z"},
 		},
+		StatusFilter: &KeyHintTOML{
+			Hint: sp("F[i]lter"),
+			Keys: &[]string{"i", "I"},
+		},
+		StatusBlockDomain: &KeyHintTOML{
+			Hint: sp("Block domain [x]"),
+			Keys: &[]string{"x", "X"},
+		},
+		StatusReport: &KeyHintTOML{
+			Hint: sp("Report [!]"),
+			Keys: &[]string{"!"},
+		},
+		StatusActAs: &KeyHintTOML{
+			Hint: sp("Act as [@]"),
+			Keys: &[]string{"@"},
+		},
+		ThreadCollapse: &KeyHintTOML{
+			Hint:    sp("Collapse [-]"),
+			HintAlt: sp("Expand [-]"),
+			Keys:    &[]string{"-"},
+		},
+		ThreadParent: &KeyHintTOML{
+			Hint: sp("Parent [<]"),
+			Keys: &[]string{"<"},
+		},
+		ThreadNextSibling: &KeyHintTOML{
+			Hint: sp("Next reply [>]"),
+			Keys: &[]string{">"},
+		},
+		HistoryDiff: &KeyHintTOML{
+			Hint:    sp("Show [d]iff"),
+			HintAlt: sp("Hide [d]iff"),
+			Keys:    &[]string{"d"},
+		},
 		UserAvatar: &KeyHintTOML{
 			Hint: sp("[A]vatar"),
 			Keys: &[]string{"a", "A"},
@@ -296,6 +335,15 @@ var ConfigDefault = ConfigTOML{
 			Hint: sp("[Y]ank"),
 			Keys: &[]string{"y", "Y"},
 		},
+		UserBlockDomain: &KeyHintTOML{
+			Hint:    sp("Block domain [x]"),
+			HintAlt: sp("Unblock domain [x]"),
+			Keys:    &[]string{"x", "X"},
+		},
+		UserReport: &KeyHintTOML{
+			Hint: sp("Report [!]"),
+			Keys: &[]string{"!"},
+		},
 		ListOpenFeed: &KeyHintTOML{
 			Hint: sp("[O]pen"),
 			Keys: &[]string{"o", "O"},
@@ -312,6 +360,27 @@ var ConfigDefault = ConfigTOML{
 			Hint: sp("[D]elete"),
 			Keys: &[]string{"d", "D"},
 		},
+		ListCreate: &KeyHintTOML{
+			Hint: sp("[N]ew"),
+			Keys: &[]string{"n", "N"},
+		},
+		ListRename: &KeyHintTOML{
+			Hint: sp("[R]ename"),
+			Keys: &[]string{"r", "R"},
+		},
+		ListDelete: &KeyHintTOML{
+			Hint: sp("[D]elete"),
+			Keys: &[]string{"d", "D"},
+		},
+		ListRepliesPolicy: &KeyHintTOML{
+			Hint: sp("Re[p]lies"),
+			Keys: &[]string{"p", "P"},
+		},
+		ListExclusive: &KeyHintTOML{
+			Hint:    sp("[E]xclusive"),
+			HintAlt: sp("Not [E]xclusive"),
+			Keys:    &[]string{"e", "E"},
+		},
 		LinkOpen: &KeyHintTOML{
 			Hint: sp("[O]pen"),
 			Keys: &[]string{"o", "O"},
@@ -329,6 +398,50 @@ var ConfigDefault = ConfigTOML{
 			HintAlt: sp("Un[F]ollow"),
 			Keys:    &[]string{"f", "F"},
 		},
+		TrendLinkOpen: &KeyHintTOML{
+			Hint: sp("[O]pen"),
+			Keys: &[]string{"o", "O"},
+		},
+		TrendLinkToots: &KeyHintTOML{
+			Hint: sp("[T]oots"),
+			Keys: &[]string{"t", "T"},
+		},
+		ScheduledEdit: &KeyHintTOML{
+			Hint: sp("[E]dit"),
+			Keys: &[]string{"e", "E"},
+		},
+		ScheduledReschedule: &KeyHintTOML{
+			Hint: sp("[R]eschedule"),
+			Keys: &[]string{"r", "R"},
+		},
+		ScheduledDelete: &KeyHintTOML{
+			Hint: sp("[D]elete"),
+			Keys: &[]string{"d", "D"},
+		},
+		DraftEdit: &KeyHintTOML{
+			Hint: sp("[E]dit"),
+			Keys: &[]string{"e", "E"},
+		},
+		DraftDelete: &KeyHintTOML{
+			Hint: sp("[D]elete"),
+			Keys: &[]string{"d", "D"},
+		},
+		FilterEdit: &KeyHintTOML{
+			Hint: sp("[E]dit"),
+			Keys: &[]string{"e", "E"},
+		},
+		FilterDelete: &KeyHintTOML{
+			Hint: sp("[D]elete"),
+			Keys: &[]string{"d", "D"},
+		},
+		FilterAddStatus: &KeyHintTOML{
+			Hint: sp("[A]dd toot"),
+			Keys: &[]string{"a", "A"},
+		},
+		DomainUnblock: &KeyHintTOML{
+			Hint: sp("[U]nblock"),
+			Keys: &[]string{"u", "U"},
+		},
 		ComposeEditCW: &KeyHintTOML{
 			Hint: sp("[C]W text"),
 			Keys: &[]string{"c", "C"},
@@ -365,6 +478,14 @@ var ConfigDefault = ConfigTOML{
 			Hint: sp("P[O]ll"),
 			Keys: &[]string{"o", "O"},
 		},
+		ComposeSchedule: &KeyHintTOML{
+			Hint: sp("[S]chedule"),
+			Keys: &[]string{"s", "S"},
+		},
+		ComposeAccounts: &KeyHintTOML{
+			Hint: sp("[A]ccounts"),
+			Keys: &[]string{"a", "A"},
+		},
 		MediaDelete: &KeyHintTOML{
 			Hint: sp("[D]elete"),
 			Keys: &[]string{"d", "D"},
@@ -377,6 +498,14 @@ var ConfigDefault = ConfigTOML{
 			Hint: sp("[A]dd"),
 			Keys: &[]string{"a", "A"},
 		},
+		CrossPostToggle: &KeyHintTOML{
+			Hint: sp("[T]oggle"),
+			Keys: &[]string{"t", "T"},
+		},
+		CrossPostVisibility: &KeyHintTOML{
+			Hint: sp("[V]isibility"),
+			Keys: &[]string{"v", "V"},
+		},
 		VoteVote: &KeyHintTOML{
 			Hint: sp("[V]ote"),
 			Keys: &[]string{"v", "V"},
@@ -438,6 +567,79 @@ var ConfigDefault = ConfigTOML{
 			Hint: sp("[D]elete"),
 			Keys: &[]string{"d", "D"},
 		},
+		FilterTitle: &KeyHintTOML{
+			Hint: sp("[T]itle"),
+			Keys: &[]string{"t", "T"},
+		},
+		FilterContext: &KeyHintTOML{
+			Hint: sp("[C]ontext"),
+			Keys: &[]string{"c", "C"},
+		},
+		FilterContextToggle: &KeyHintTOML{
+			Hint: sp("[T]oggle"),
+			Keys: &[]string{"t", "T"},
+		},
+		FilterAction: &KeyHintTOML{
+			Hint: sp("[A]ction"),
+			Keys: &[]string{"a", "A"},
+		},
+		FilterExpires: &KeyHintTOML{
+			Hint: sp("E[x]pires"),
+			Keys: &[]string{"x", "X"},
+		},
+		FilterKeywords: &KeyHintTOML{
+			Hint: sp("[K]eywords"),
+			Keys: &[]string{"k", "K"},
+		},
+		FilterKeywordAdd: &KeyHintTOML{
+			Hint: sp("[A]dd"),
+			Keys: &[]string{"a", "A"},
+		},
+		FilterKeywordEdit: &KeyHintTOML{
+			Hint: sp("[E]dit"),
+			Keys: &[]string{"e", "E"},
+		},
+		FilterKeywordDelete: &KeyHintTOML{
+			Hint: sp("[D]elete"),
+			Keys: &[]string{"d", "D"},
+		},
+		FilterKeywordWholeWord: &KeyHintTOML{
+			Hint: sp("[W]hole word"),
+			Keys: &[]string{"w", "W"},
+		},
+		FilterSave: &KeyHintTOML{
+			Hint: sp("[S]ave"),
+			Keys: &[]string{"s", "S"},
+		},
+		ReportCategory: &KeyHintTOML{
+			Hint: sp("[C]ategory"),
+			Keys: &[]string{"c", "C"},
+		},
+		ReportRules: &KeyHintTOML{
+			Hint: sp("[R]ules"),
+			Keys: &[]string{"r", "R"},
+		},
+		ReportToots: &KeyHintTOML{
+			Hint: sp("[T]oots"),
+			Keys: &[]string{"t", "T"},
+		},
+		ReportToggle: &KeyHintTOML{
+			Hint: sp("[T]oggle"),
+			Keys: &[]string{"t", "T"},
+		},
+		ReportComment: &KeyHintTOML{
+			Hint: sp("Co[m]ment"),
+			Keys: &[]string{"m", "M"},
+		},
+		ReportForward: &KeyHintTOML{
+			Hint:    sp("[F]orward"),
+			HintAlt: sp("Don't [f]orward"),
+			Keys:    &[]string{"f", "F"},
+		},
+		ReportSend: &KeyHintTOML{
+			Hint: sp("[S]end"),
+			Keys: &[]string{"s", "S"},
+		},
 		EditorExit: &KeyHintTOML{
 			Hint:        sp("[Esc] when done"),
 			SpecialKeys: &[]string{"Esc"},
@@ -3,7 +3,7 @@ package feed
 import (
 	"context"
 	"errors"
-	"log"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
@@ -11,6 +11,7 @@ import (
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
 	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/util"
 	"golang.org/x/exp/slices"
 )
 
@@ -23,12 +24,35 @@ type apiSearchFunc func(search string) ([]api.Item, error)
 type apiSearchPGFunc func(pg *mastodon.Pagination, search string) ([]api.Item, error)
 type apiThreadFunc func(status *mastodon.Status) ([]api.Item, error)
 type apiHistoryFunc func(status *mastodon.Status) ([]api.Item, error)
+type apiSearchTypeFunc func(search string, st api.SearchType, offset int) ([]api.Item, error)
+type apiOffsetFunc func(offset int) ([]api.Item, error)
+
+// openFeeds holds the open feeds of each account, so changes that comes from
+// a stream can be applied to feeds that don't have a stream of their own.
+var openFeeds = make(map[*api.AccountClient][]*Feed)
+var openFeedsMux sync.Mutex
+
+// The max number of pages to load when a stream has been reconnected.
+const backfillPages = 10
 
 type LoadingLock struct {
 	mux  sync.Mutex
 	last time.Time
 }
 
+type searchGroup struct {
+	searchType api.SearchType
+	offset     int
+	done       bool
+}
+
+// offsetPages keeps track of feeds that are paginated with an offset instead
+// of IDs, like the trends.
+type offsetPages struct {
+	offset int
+	done   bool
+}
+
 type DesktopNotificationType uint
 
 const (
@@ -53,6 +77,7 @@ type Feed struct {
 	feedType      config.FeedType
 	sticky        []api.Item
 	items         []api.Item
+	edited        []uint
 	itemsMux      sync.RWMutex
 	loadingNewer  *LoadingLock
 	loadingOlder  *LoadingLock
@@ -62,7 +87,17 @@ type Feed struct {
 	apiData       *api.RequestData
 	apiDataMux    sync.Mutex
 	streams       []*api.Receiver
+	pollInterval  time.Duration
+	pollStop      chan struct{}
+	pollMux       sync.Mutex
 	name          string
+	list          *mastodon.List
+	thread        *threadState
+	marker        *markerState
+	unified       *unifiedState
+	unseen        map[uint]bool
+	loadedNewer   bool
+	loadErr       error
 	close         func()
 	hideBoosts    bool
 	hideReplies   bool
@@ -76,9 +111,18 @@ func (f *Feed) filteredList() []api.Item {
 	f.itemsMux.RLock()
 	defer f.itemsMux.RUnlock()
 	filtered := []api.Item{}
+	skip := 0
 	for _, fd := range f.items {
-		switch x := fd.Raw().(type) {
-		case *mastodon.Status:
+		if skip > 0 {
+			skip--
+			continue
+		}
+		if s, ok := fd.(*api.StatusItem); ok {
+			if tp := s.ThreadPosition(); tp != nil && tp.Collapsed {
+				skip = tp.Descendants
+			}
+		}
+		if x, ok := fd.Raw().(*mastodon.Status); ok {
 			if f.Type() == config.TimelineHomeSpecial && x.Reblog == nil && x.InReplyToID == nil {
 				continue
 			}
@@ -89,7 +133,7 @@ func (f *Feed) filteredList() []api.Item {
 				continue
 			}
 		}
-		inUse, fType, _, _ := fd.Filtered(f.feedType)
+		inUse, fType, _, _ := fd.Filtered(f.FilterType())
 		if inUse && fType == "hide" {
 			continue
 		}
@@ -99,6 +143,121 @@ func (f *Feed) filteredList() []api.Item {
 	return append(r, filtered...)
 }
 
+// matchClientFilters sets the filter from the config that matches each status
+// in items. It's done once when the items are added to the feed, as the
+// regexes are slow. Call it with itemsMux locked.
+func (f *Feed) matchClientFilters(items []api.Item) {
+	if len(f.config.Filters) == 0 {
+		return
+	}
+	for _, item := range items {
+		switch x := item.Raw().(type) {
+		case *api.NotificationData:
+			if s, ok := x.Status.(*api.StatusItem); ok {
+				s.SetClientFilter(f.clientFilter(s.Raw().(*mastodon.Status)))
+			}
+		case *mastodon.Status:
+			if s, ok := item.(*api.StatusItem); ok {
+				s.SetClientFilter(f.clientFilter(x))
+			}
+		}
+	}
+}
+
+// appendOlder adds items that are older than the items in the feed. Call it
+// with itemsMux locked.
+func (f *Feed) appendOlder(items []api.Item) {
+	f.matchClientFilters(items)
+	f.items = append(f.items, items...)
+}
+
+// clientFilter returns the filter from the config that matches the status in
+// this feed. Hide filters are returned before warn filters.
+func (f *Feed) clientFilter(status *mastodon.Status) *config.Filter {
+	if status == nil || len(f.config.Filters) == 0 {
+		return nil
+	}
+	s := util.StatusOrReblog(status)
+	var match *config.Filter
+	for i := range f.config.Filters {
+		cf := &f.config.Filters[i]
+		if len(cf.Timelines) > 0 && !slices.Contains(cf.Timelines, f.FilterType()) {
+			continue
+		}
+		if !f.matchFilter(cf, s) {
+			continue
+		}
+		if cf.Action == "hide" {
+			return cf
+		}
+		if match == nil {
+			match = cf
+		}
+	}
+	return match
+}
+
+func (f *Feed) matchFilter(cf *config.Filter, s *mastodon.Status) bool {
+	if len(cf.Accounts) == 0 && len(cf.Domains) == 0 && len(cf.Languages) == 0 &&
+		cf.Regex == nil && cf.Media == nil {
+		return false
+	}
+	acct := strings.ToLower(s.Account.Acct)
+	domain := ""
+	if i := strings.LastIndex(acct, "@"); i != -1 {
+		domain = acct[i+1:]
+	} else if u, err := url.Parse(f.accountClient.Client.Config.Server); err == nil {
+		domain = strings.ToLower(u.Hostname())
+	}
+	if len(cf.Accounts) > 0 {
+		found := false
+		for _, a := range cf.Accounts {
+			if a == acct || (!strings.Contains(acct, "@") && a == acct+"@"+domain) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(cf.Domains) > 0 {
+		found := false
+		for _, d := range cf.Domains {
+			if domain == d || strings.HasSuffix(domain, "."+d) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(cf.Languages) > 0 {
+		lang := strings.ToLower(s.Language)
+		found := false
+		for _, l := range cf.Languages {
+			if lang == l || strings.HasPrefix(lang, l+"-") {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if cf.Media != nil && (len(s.MediaAttachments) > 0) != *cf.Media {
+		return false
+	}
+	if cf.Regex != nil {
+		text, _ := util.CleanHTML(s.Content)
+		if !cf.Regex.MatchString(s.SpoilerText + "\n" + text) {
+			return false
+		}
+	}
+	return true
+}
+
 func (f *Feed) List() []api.Item {
 	return f.filteredList()
 }
@@ -112,14 +271,25 @@ func (f *Feed) Delete(id uint) {
 			items = append(items, item)
 		}
 	}
+	delete(f.unseen, id)
 	f.items = items
 	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 }
 
+// Prepend adds items to the top of the feed.
+func (f *Feed) Prepend(items ...api.Item) {
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	f.matchClientFilters(items)
+	f.items = append(items, f.items...)
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+}
+
 func (f *Feed) Clear() {
 	f.itemsMux.Lock()
 	defer f.itemsMux.Unlock()
 	f.items = []api.Item{}
+	f.unseen = nil
 	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 }
 
@@ -161,6 +331,9 @@ func (f *Feed) LoadNewer() {
 		return
 	}
 	f.loadNewer()
+	f.itemsMux.Lock()
+	f.loadedNewer = true
+	f.itemsMux.Unlock()
 	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	f.loadingNewer.last = time.Now()
 	f.loadingNewer.mux.Unlock()
@@ -192,37 +365,138 @@ func (f *Feed) Close() {
 	if f.close != nil {
 		f.close()
 	}
+	f.stopPolling()
+	openFeedsMux.Lock()
+	defer openFeedsMux.Unlock()
+	feeds := openFeeds[f.accountClient]
+	for i, of := range feeds {
+		if of == f {
+			openFeeds[f.accountClient] = append(feeds[:i], feeds[i+1:]...)
+			break
+		}
+	}
 }
 
 func (f *Feed) Name() string {
+	if f.list != nil {
+		return f.list.Title
+	}
 	return f.name
 }
 
+// ListData returns the list shown by List, ListUsersIn and ListUsersAdd
+// feeds and nil for all other feeds.
+func (f *Feed) ListData() *mastodon.List {
+	return f.list
+}
+
 func (f *Feed) StickyCount() int {
 	return len(f.sticky)
 }
 
+// loadFailed keeps err so the UI can show it, see LoadError.
+func (f *Feed) loadFailed(err error) {
+	f.itemsMux.Lock()
+	f.loadErr = err
+	f.itemsMux.Unlock()
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+}
+
+// LoadError returns the error from the last load that failed, once.
+func (f *Feed) LoadError() error {
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	err := f.loadErr
+	f.loadErr = nil
+	return err
+}
+
 func (f *Feed) singleNewerSearch(fn apiSearchFunc, search string) {
 	items, err := fn(search)
 	if err != nil {
+		f.loadFailed(err)
 		return
 	}
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
 }
 
-func (f *Feed) singleThread(fn apiThreadFunc, status *mastodon.Status) {
-	items, err := fn(status)
+func (f *Feed) searchOlder(fn apiSearchTypeFunc, search string, groups []*searchGroup) {
+	loaded := make(map[api.SearchType][]api.Item)
+	var loadErr error
+	f.apiDataMux.Lock()
+	for _, g := range groups {
+		if g.done {
+			continue
+		}
+		items, err := fn(search, g.searchType, g.offset)
+		if err != nil {
+			loadErr = err
+			continue
+		}
+		if len(items) == 0 {
+			g.done = true
+			continue
+		}
+		g.offset += len(items)
+		loaded[g.searchType] = items
+	}
+	f.apiDataMux.Unlock()
+	if loadErr != nil {
+		f.loadFailed(loadErr)
+	}
+	if len(loaded) == 0 {
+		return
+	}
+	f.itemsMux.Lock()
+	grouped := make(map[api.SearchType][]api.Item)
+	for _, item := range f.items {
+		switch item.Type() {
+		case api.StatusType:
+			grouped[api.SearchStatuses] = append(grouped[api.SearchStatuses], item)
+		case api.UserType:
+			grouped[api.SearchAccounts] = append(grouped[api.SearchAccounts], item)
+		case api.TagType:
+			grouped[api.SearchHashtags] = append(grouped[api.SearchHashtags], item)
+		}
+	}
+	for _, items := range loaded {
+		f.matchClientFilters(items)
+	}
+	items := []api.Item{}
+	for _, g := range groups {
+		items = append(items, grouped[g.searchType]...)
+		items = append(items, loaded[g.searchType]...)
+	}
+	f.items = items
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+	f.itemsMux.Unlock()
+}
+
+func (f *Feed) offsetOlder(fn apiOffsetFunc, pages *offsetPages) {
+	f.apiDataMux.Lock()
+	if pages.done {
+		f.apiDataMux.Unlock()
+		return
+	}
+	items, err := fn(pages.offset)
 	if err != nil {
+		f.apiDataMux.Unlock()
 		return
 	}
+	if len(items) == 0 {
+		pages.done = true
+	}
+	pages.offset += len(items)
+	f.apiDataMux.Unlock()
+
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(items, f.items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -235,7 +509,7 @@ func (f *Feed) singleHistory(fn apiHistoryFunc, status *mastodon.Status) {
 	}
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -268,7 +542,7 @@ func (f *Feed) normalNewer(fn apiFunc) {
 				f.apiData.MaxID = item.Item.ID
 			}
 		}
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -297,7 +571,7 @@ func (f *Feed) normalOlder(fn apiFunc) {
 		case *api.NotificationData:
 			f.apiData.MaxID = item.Item.ID
 		}
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -331,7 +605,7 @@ func (f *Feed) normalNewerNotification(fn apiFuncNotification, nth []config.Noti
 				f.apiData.MaxID = item.Item.ID
 			}
 		}
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -360,7 +634,7 @@ func (f *Feed) normalOlderNotification(fn apiFuncNotification, nth []config.Noti
 		case *api.NotificationData:
 			f.apiData.MaxID = item.Item.ID
 		}
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -382,7 +656,7 @@ func (f *Feed) newerSearchPG(fn apiSearchPGFunc, search string) {
 	if len(items) > 0 {
 		item := items[0].Raw().(*mastodon.Status)
 		f.apiData.MinID = item.ID
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 		if f.apiData.MaxID == mastodon.ID("") {
 			item = items[len(items)-1].Raw().(*mastodon.Status)
@@ -411,7 +685,7 @@ func (f *Feed) olderSearchPG(fn apiSearchPGFunc, search string) {
 	if len(items) > 0 {
 		item := items[len(items)-1].Raw().(*mastodon.Status)
 		f.apiData.MaxID = item.ID
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -433,7 +707,7 @@ func (f *Feed) normalNewerUser(fn apiIDFunc, id mastodon.ID) {
 	if len(items) > 0 {
 		item := items[0].Raw().(*mastodon.Status)
 		f.apiData.MinID = item.ID
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 		if f.apiData.MaxID == mastodon.ID("") {
 			item = items[len(items)-1].Raw().(*mastodon.Status)
@@ -462,7 +736,7 @@ func (f *Feed) normalOlderUser(fn apiIDFunc, id mastodon.ID) {
 	if len(items) > 0 {
 		item := items[len(items)-1].Raw().(*mastodon.Status)
 		f.apiData.MaxID = item.ID
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -484,7 +758,7 @@ func (f *Feed) normalNewerID(fn apiIDFunc, id mastodon.ID) {
 	if len(items) > 0 {
 		item := items[0].Raw().(*mastodon.Status)
 		f.apiData.MinID = item.ID
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 		if f.apiData.MaxID == mastodon.ID("") {
 			item = items[len(items)-1].Raw().(*mastodon.Status)
@@ -513,7 +787,7 @@ func (f *Feed) normalOlderID(fn apiIDFunc, id mastodon.ID) {
 	if len(items) > 0 {
 		item := items[len(items)-1].Raw().(*mastodon.Status)
 		f.apiData.MaxID = item.ID
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -527,7 +801,7 @@ func (f *Feed) normalEmpty(fn apiEmptyFunc) {
 	}
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -553,7 +827,7 @@ func (f *Feed) linkNewer(fn apiFunc) {
 	f.apiDataMux.Unlock()
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -578,7 +852,7 @@ func (f *Feed) linkOlder(fn apiFunc) {
 
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -604,7 +878,7 @@ func (f *Feed) linkNewerID(fn apiIDFunc, id mastodon.ID) {
 	f.apiDataMux.Unlock()
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -629,7 +903,7 @@ func (f *Feed) linkOlderID(fn apiIDFunc, id mastodon.ID) {
 
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -655,7 +929,7 @@ func (f *Feed) linkNewerIDdata(fn apiIDFuncData, id mastodon.ID, data interface{
 	f.apiDataMux.Unlock()
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(items, f.items...)
+		f.prependNewer(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -680,7 +954,7 @@ func (f *Feed) linkOlderIDdata(fn apiIDFuncData, id mastodon.ID, data interface{
 
 	f.itemsMux.Lock()
 	if len(items) > 0 {
-		f.items = append(f.items, items...)
+		f.appendOlder(items)
 		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
 	}
 	f.itemsMux.Unlock()
@@ -688,11 +962,23 @@ func (f *Feed) linkOlderIDdata(fn apiIDFuncData, id mastodon.ID, data interface{
 
 func (f *Feed) startStream(rec *api.Receiver, timeline string, err error) {
 	if err != nil {
-		log.Fatalln("Couldn't open stream")
+		return
 	}
 	f.streams = append(f.streams, rec)
 	go func() {
-		for e := range rec.Ch {
+		reconnected := false
+		// The stream may already have failed if it's shared with another feed.
+		f.streamStateChanged(rec, &reconnected)
+		for {
+			var e mastodon.Event
+			select {
+			case <-rec.Done():
+				return
+			case <-rec.State:
+				f.streamStateChanged(rec, &reconnected)
+				continue
+			case e = <-rec.Ch:
+			}
 			switch t := e.(type) {
 			case *mastodon.ConversationEvent:
 				if t.Conversation.LastStatus == nil {
@@ -713,7 +999,7 @@ func (f *Feed) startStream(rec *api.Receiver, timeline string, err error) {
 					}
 				}
 				if !found {
-					f.items = append([]api.Item{s}, f.items...)
+					f.prependNewer([]api.Item{s})
 					f.Updated(DesktopNotificationHolder{
 						Type: DesktopNotificationMention,
 					})
@@ -736,13 +1022,18 @@ func (f *Feed) startStream(rec *api.Receiver, timeline string, err error) {
 					}
 				}
 				if !found {
-					f.items = append([]api.Item{s}, f.items...)
+					f.prependNewer([]api.Item{s})
 					f.Updated(DesktopNotificationHolder{
 						Type: DesktopNotificationPost,
 					})
 					f.apiData.MinID = t.Status.ID
 				}
 				f.itemsMux.Unlock()
+				addReply(f.accountClient, t.Status)
+			case *mastodon.UpdateEditEvent:
+				updateStatus(f.accountClient, t.Status)
+			case *mastodon.DeleteEvent:
+				removeStatus(f.accountClient, t.ID)
 			}
 		}
 	}()
@@ -750,13 +1041,32 @@ func (f *Feed) startStream(rec *api.Receiver, timeline string, err error) {
 
 func (f *Feed) startStreamNotification(rec *api.Receiver, timeline string, err error, mentions bool) {
 	if err != nil {
-		log.Fatalln("Couldn't open stream")
+		return
 	}
 	f.streams = append(f.streams, rec)
 	go func() {
-		for e := range rec.Ch {
+		reconnected := false
+		// The stream may already have failed if it's shared with another feed.
+		f.streamStateChanged(rec, &reconnected)
+		for {
+			var e mastodon.Event
+			select {
+			case <-rec.Done():
+				return
+			case <-rec.State:
+				f.streamStateChanged(rec, &reconnected)
+				continue
+			case e = <-rec.Ch:
+			}
 			switch t := e.(type) {
+			case *mastodon.UpdateEditEvent:
+				updateStatus(f.accountClient, t.Status)
+			case *mastodon.DeleteEvent:
+				removeStatus(f.accountClient, t.ID)
 			case *mastodon.NotificationEvent:
+				if t.Notification.Type == "mention" {
+					addReply(f.accountClient, t.Notification.Status)
+				}
 				switch t.Notification.Type {
 				case "follow":
 					if slices.Contains(f.config.General.NotificationsToHide, config.HideFollow) || mentions {
@@ -796,7 +1106,6 @@ func (f *Feed) startStreamNotification(rec *api.Receiver, timeline string, err e
 					continue
 				}
 				if len(rel) == 0 {
-					log.Fatalln(t.Notification.Account.Acct)
 					continue
 				}
 				s := api.NewNotificationItem(t.Notification,
@@ -805,7 +1114,7 @@ func (f *Feed) startStreamNotification(rec *api.Receiver, timeline string, err e
 						Relation: rel[0],
 					})
 				f.itemsMux.Lock()
-				f.items = append([]api.Item{s}, f.items...)
+				f.prependNewer([]api.Item{s})
 				nft := DesktopNotificationNone
 				data := t.Notification.Account.DisplayName
 				switch t.Notification.Type {
@@ -836,8 +1145,224 @@ func (f *Feed) startStreamNotification(rec *api.Receiver, timeline string, err e
 	}()
 }
 
+// updateStatus applies an edit from a stream to all open feeds of the
+// account.
+func updateStatus(ac *api.AccountClient, status *mastodon.Status) {
+	for _, f := range accountFeeds(ac) {
+		f.updateStatus(status)
+	}
+}
+
+// removeStatus removes a deleted status, boosts of it and notifications
+// about it from all open feeds of the account.
+func removeStatus(ac *api.AccountClient, id mastodon.ID) {
+	for _, f := range accountFeeds(ac) {
+		f.removeStatus(id)
+	}
+}
+
+func accountFeeds(ac *api.AccountClient) []*Feed {
+	openFeedsMux.Lock()
+	defer openFeedsMux.Unlock()
+	return append([]*Feed{}, openFeeds[ac]...)
+}
+
+func (f *Feed) updateStatus(status *mastodon.Status) {
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	updated := false
+	for _, items := range [][]api.Item{f.sticky, f.items} {
+		for _, item := range items {
+			ok := false
+			switch item.Type() {
+			case api.StatusType:
+				ok = item.(*api.StatusItem).UpdateStatus(status)
+			case api.NotificationType:
+				nd := item.Raw().(*api.NotificationData)
+				ok = nd.Status.(*api.StatusItem).UpdateStatus(status)
+			}
+			if ok {
+				f.matchClientFilters([]api.Item{item})
+				f.edited = append(f.edited, item.ID())
+				updated = true
+			}
+		}
+	}
+	if updated {
+		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+	}
+}
+
+// EditedItems returns the IDs of the items that have been edited since the
+// last call.
+func (f *Feed) EditedItems() []uint {
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	edited := f.edited
+	f.edited = nil
+	return edited
+}
+
+func (f *Feed) removeStatus(id mastodon.ID) {
+	keep := func(item api.Item) bool {
+		var s *mastodon.Status
+		switch item.Type() {
+		case api.StatusType:
+			s = item.Raw().(*mastodon.Status)
+		case api.NotificationType:
+			s = item.Raw().(*api.NotificationData).Item.Status
+		}
+		if s == nil {
+			return true
+		}
+		return s.ID != id && (s.Reblog == nil || s.Reblog.ID != id)
+	}
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	removed := false
+	var sticky []api.Item
+	for _, item := range f.sticky {
+		if keep(item) {
+			sticky = append(sticky, item)
+		} else {
+			removed = true
+		}
+	}
+	var items []api.Item
+	for _, item := range f.items {
+		if keep(item) {
+			items = append(items, item)
+		} else {
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+	f.sticky = append(make([]api.Item, 0), sticky...)
+	f.items = append(make([]api.Item, 0), items...)
+	f.orderThread()
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+}
+
+// streamStateChanged redraws the feed so the UI can show the state of the
+// stream. When a stream comes back after being disconnected the items that
+// were posted in the meantime are loaded.
+func (f *Feed) streamStateChanged(rec *api.Receiver, disconnected *bool) {
+	state, _ := rec.StreamState()
+	switch state {
+	case api.StreamDisconnected:
+		*disconnected = true
+		if rec.StreamFailed() {
+			f.startPolling()
+		}
+	case api.StreamConnected:
+		f.stopPolling()
+		if *disconnected {
+			f.backfill()
+		}
+		*disconnected = false
+	}
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+}
+
+// backfill loads newer items page by page from apiData.MinID until there
+// are no more or backfillPages have been loaded.
+func (f *Feed) backfill() {
+	f.loadingNewer.mux.Lock()
+	defer f.loadingNewer.mux.Unlock()
+	for i := 0; i < backfillPages; i++ {
+		f.itemsMux.RLock()
+		before := len(f.items)
+		f.itemsMux.RUnlock()
+		f.loadNewer()
+		f.itemsMux.RLock()
+		after := len(f.items)
+		f.itemsMux.RUnlock()
+		if after == before {
+			break
+		}
+	}
+	f.loadingNewer.last = time.Now()
+}
+
+// SetPollInterval sets how often newer items are loaded when the stream of the
+// feed doesn't work. Zero or less uses the default.
+func (f *Feed) SetPollInterval(d time.Duration) {
+	f.pollMux.Lock()
+	defer f.pollMux.Unlock()
+	f.pollInterval = d
+}
+
+func (f *Feed) getPollInterval() time.Duration {
+	f.pollMux.Lock()
+	defer f.pollMux.Unlock()
+	if f.pollInterval <= 0 {
+		return time.Duration(config.DefaultPollInterval) * time.Second
+	}
+	return f.pollInterval
+}
+
+// Polling returns true if the feed loads newer items on an interval because
+// its stream isn't working.
+func (f *Feed) Polling() bool {
+	f.pollMux.Lock()
+	defer f.pollMux.Unlock()
+	return f.pollStop != nil
+}
+
+func (f *Feed) startPolling() {
+	f.pollMux.Lock()
+	defer f.pollMux.Unlock()
+	if f.pollStop != nil {
+		return
+	}
+	stop := make(chan struct{})
+	f.pollStop = stop
+	go func() {
+		for {
+			select {
+			case <-stop:
+				return
+			case <-time.After(f.getPollInterval()):
+			}
+			f.LoadNewer()
+		}
+	}()
+}
+
+func (f *Feed) stopPolling() {
+	f.pollMux.Lock()
+	defer f.pollMux.Unlock()
+	if f.pollStop == nil {
+		return
+	}
+	close(f.pollStop)
+	f.pollStop = nil
+}
+
+// StreamState returns the state of the feeds streams. If any of them isn't
+// connected that state is returned. The bool is false if the feed doesn't
+// have any streams.
+func (f *Feed) StreamState() (api.StreamState, bool) {
+	if len(f.streams) == 0 {
+		return api.StreamConnected, false
+	}
+	state := api.StreamConnected
+	for _, rec := range f.streams {
+		s, _ := rec.StreamState()
+		switch s {
+		case api.StreamDisconnected:
+			return s, true
+		case api.StreamConnecting:
+			state = s
+		}
+	}
+	return state, true
+}
+
 func newFeed(ac *api.AccountClient, ft config.FeedType, cnf *config.Config, hideBoosts bool, hideReplies bool) *Feed {
-	return &Feed{
+	f := &Feed{
 		accountClient: ac,
 		config:        cnf,
 		sticky:        make([]api.Item, 0),
@@ -852,10 +1377,15 @@ func newFeed(ac *api.AccountClient, ft config.FeedType, cnf *config.Config, hide
 		hideBoosts:    hideBoosts,
 		hideReplies:   hideReplies,
 	}
+	openFeedsMux.Lock()
+	openFeeds[ac] = append(openFeeds[ac], f)
+	openFeedsMux.Unlock()
+	return f
 }
 
 func NewTimelineHome(ac *api.AccountClient, cnf *config.Config, hideBoosts bool, hideReplies bool) *Feed {
 	feed := newFeed(ac, config.TimelineHome, cnf, hideBoosts, hideReplies)
+	feed.loadMarker("home")
 	feed.loadNewer = func() { feed.normalNewer(feed.accountClient.GetTimeline) }
 	feed.loadOlder = func() { feed.normalOlder(feed.accountClient.GetTimeline) }
 	feed.startStream(feed.accountClient.NewHomeStream())
@@ -925,6 +1455,7 @@ func NewConversations(ac *api.AccountClient, cnf *config.Config) *Feed {
 
 func NewNotifications(ac *api.AccountClient, cnf *config.Config, hideBoosts bool, hideReplies bool) *Feed {
 	feed := newFeed(ac, config.Notifications, cnf, hideBoosts, hideReplies)
+	feed.loadMarker("notifications")
 	feed.loadNewer = func() {
 		feed.normalNewerNotification(feed.accountClient.GetNotifications, cnf.General.NotificationsToHide)
 	}
@@ -984,6 +1515,33 @@ func NewUserSearch(ac *api.AccountClient, cnf *config.Config, search string) *Fe
 	return feed
 }
 
+func NewSearch(ac *api.AccountClient, cnf *config.Config, search string, statusesOnly bool) *Feed {
+	feed := newFeed(ac, config.Search, cnf, false, false)
+	feed.name = search
+	groups := []*searchGroup{
+		{searchType: api.SearchStatuses},
+	}
+	if !statusesOnly {
+		groups = append(groups,
+			&searchGroup{searchType: api.SearchAccounts},
+			&searchGroup{searchType: api.SearchHashtags},
+		)
+	}
+	once := true
+	feed.loadNewer = func() {
+		feed.apiDataMux.Lock()
+		first := once
+		once = false
+		feed.apiDataMux.Unlock()
+		if first {
+			feed.searchOlder(feed.accountClient.GetSearch, search, groups)
+		}
+	}
+	feed.loadOlder = func() { feed.searchOlder(feed.accountClient.GetSearch, search, groups) }
+
+	return feed
+}
+
 func NewUserProfile(ac *api.AccountClient, cnf *config.Config, user *api.User) *Feed {
 	feed := newFeed(ac, config.User, cnf, false, false)
 	feed.name = user.Data.Acct
@@ -1000,13 +1558,8 @@ func NewUserProfile(ac *api.AccountClient, cnf *config.Config, user *api.User) *
 
 func NewThread(ac *api.AccountClient, cnf *config.Config, status *mastodon.Status) *Feed {
 	feed := newFeed(ac, config.Thread, cnf, false, false)
-	once := true
-	feed.loadNewer = func() {
-		if once {
-			feed.singleThread(feed.accountClient.GetThread, status)
-			once = false
-		}
-	}
+	feed.thread = &threadState{collapsed: make(map[mastodon.ID]bool)}
+	feed.loadNewer = func() { feed.threadNewer(feed.accountClient.GetThread, status) }
 
 	return feed
 }
@@ -1078,7 +1631,7 @@ func NewListList(ac *api.AccountClient, cnf *config.Config) *Feed {
 
 func NewList(ac *api.AccountClient, cnf *config.Config, list *mastodon.List, hideBoosts bool, hideReplies bool) *Feed {
 	feed := newFeed(ac, config.List, cnf, hideBoosts, hideReplies)
-	feed.name = list.Title
+	feed.list = list
 	feed.loadNewer = func() { feed.normalNewerID(feed.accountClient.GetListStatuses, list.ID) }
 	feed.loadOlder = func() { feed.normalOlderID(feed.accountClient.GetListStatuses, list.ID) }
 	feed.startStream(feed.accountClient.NewListStream(list.ID))
@@ -1093,7 +1646,7 @@ func NewList(ac *api.AccountClient, cnf *config.Config, list *mastodon.List, hid
 
 func NewUsersInList(ac *api.AccountClient, cnf *config.Config, list *mastodon.List) *Feed {
 	feed := newFeed(ac, config.ListUsersIn, cnf, false, false)
-	feed.name = list.Title
+	feed.list = list
 	once := true
 	feed.loadNewer = func() {
 		if once {
@@ -1108,7 +1661,7 @@ func NewUsersInList(ac *api.AccountClient, cnf *config.Config, list *mastodon.Li
 
 func NewUsersAddList(ac *api.AccountClient, cnf *config.Config, list *mastodon.List) *Feed {
 	feed := newFeed(ac, config.ListUsersAdd, cnf, false, false)
-	feed.name = list.Title
+	feed.list = list
 	once := true
 	feed.loadNewer = func() {
 		if once {
@@ -1216,3 +1769,111 @@ func NewFollowRequests(ac *api.AccountClient, cnf *config.Config) *Feed {
 
 	return feed
 }
+
+func NewScheduled(ac *api.AccountClient, cnf *config.Config) *Feed {
+	feed := newFeed(ac, config.Scheduled, cnf, false, false)
+	once := true
+	feed.loadNewer = func() {
+		if once {
+			feed.linkNewer(feed.accountClient.GetScheduledStatuses)
+		}
+		once = false
+	}
+	feed.loadOlder = func() { feed.linkOlder(feed.accountClient.GetScheduledStatuses) }
+
+	return feed
+}
+
+func NewFilters(ac *api.AccountClient, cnf *config.Config) *Feed {
+	feed := newFeed(ac, config.Filters, cnf, false, false)
+	once := true
+	feed.loadNewer = func() {
+		if once {
+			feed.normalEmpty(feed.accountClient.GetFilters)
+		}
+		once = false
+	}
+
+	return feed
+}
+
+func NewDomainBlocks(ac *api.AccountClient, cnf *config.Config) *Feed {
+	feed := newFeed(ac, config.DomainBlocks, cnf, false, false)
+	once := true
+	feed.loadNewer = func() {
+		if once {
+			feed.linkNewer(feed.accountClient.GetDomainBlocks)
+		}
+		once = false
+	}
+	feed.loadOlder = func() { feed.linkOlder(feed.accountClient.GetDomainBlocks) }
+
+	return feed
+}
+
+func newTrending(ac *api.AccountClient, ft config.FeedType, cnf *config.Config, fn apiOffsetFunc) *Feed {
+	feed := newFeed(ac, ft, cnf, false, false)
+	pages := &offsetPages{}
+	once := true
+	feed.loadNewer = func() {
+		if once {
+			feed.offsetOlder(fn, pages)
+		}
+		once = false
+	}
+	feed.loadOlder = func() { feed.offsetOlder(fn, pages) }
+
+	return feed
+}
+
+func NewTrendingStatuses(ac *api.AccountClient, cnf *config.Config) *Feed {
+	return newTrending(ac, config.TrendingStatuses, cnf, ac.GetTrendingStatuses)
+}
+
+func NewTrendingTags(ac *api.AccountClient, cnf *config.Config) *Feed {
+	return newTrending(ac, config.TrendingTags, cnf, ac.GetTrendingTags)
+}
+
+func NewTrendingLinks(ac *api.AccountClient, cnf *config.Config) *Feed {
+	return newTrending(ac, config.TrendingLinks, cnf, ac.GetTrendingLinks)
+}
+
+func NewLink(ac *api.AccountClient, cnf *config.Config, link string) *Feed {
+	feed := newFeed(ac, config.Link, cnf, false, false)
+	feed.name = link
+	fn := func(pg *mastodon.Pagination) ([]api.Item, error) {
+		return feed.accountClient.GetLinkStatuses(pg, link)
+	}
+	feed.loadNewer = func() { feed.linkNewer(fn) }
+	feed.loadOlder = func() { feed.linkOlder(fn) }
+
+	return feed
+}
+
+func NewFiltersStatus(ac *api.AccountClient, cnf *config.Config, status *mastodon.Status) *Feed {
+	feed := newFeed(ac, config.FiltersStatus, cnf, false, false)
+	once := true
+	feed.loadNewer = func() {
+		if once {
+			feed.normalEmpty(func() ([]api.Item, error) {
+				return feed.accountClient.GetFiltersStatus(status)
+			})
+		}
+		once = false
+	}
+
+	return feed
+}
+
+func NewDrafts(ac *api.AccountClient, cnf *config.Config) *Feed {
+	feed := newFeed(ac, config.Drafts, cnf, false, false)
+	once := true
+	feed.loadNewer = func() {
+		if once {
+			feed.normalEmpty(feed.accountClient.GetDrafts)
+		}
+		once = false
+	}
+
+	return feed
+}
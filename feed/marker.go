@@ -0,0 +1,191 @@
+package feed
+
+import (
+	"sync"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+)
+
+// How long to wait after you have moved to a newer item before the marker is
+// saved, so it isn't saved for every item you scroll past.
+const markerSaveDelay = 2 * time.Second
+
+// markerMaxPages is how many pages that are loaded at most when tut starts to
+// find where you stopped reading.
+const markerMaxPages = 5
+
+// markerState is how far you have read in a home or notifications feed. It's
+// synced with the markers on the instance.
+type markerState struct {
+	mux      sync.Mutex
+	timeline string
+	start    mastodon.ID
+	lastRead mastodon.ID
+	saved    mastodon.ID
+	saving   bool
+}
+
+// compareID compares two ids from the same instance. The ids are numbers,
+// but they are too large to parse on some instances, so they are compared as
+// strings.
+func compareID(a, b mastodon.ID) int {
+	switch {
+	case len(a) != len(b):
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func markerID(item api.Item) mastodon.ID {
+	switch v := item.Raw().(type) {
+	case *mastodon.Status:
+		return v.ID
+	case *api.NotificationData:
+		return v.Item.ID
+	}
+	return ""
+}
+
+// loadMarker gets the marker for timeline from the instance. Call it before the
+// feed loads any items.
+func (f *Feed) loadMarker(timeline string) {
+	if !f.config.General.SyncMarkers {
+		return
+	}
+	f.marker = &markerState{timeline: timeline}
+	m, err := f.accountClient.GetMarker(timeline)
+	if err != nil || m == nil {
+		return
+	}
+	f.marker.start = m.LastReadID
+	f.marker.lastRead = m.LastReadID
+	f.marker.saved = m.LastReadID
+}
+
+// MarkRead moves the marker to item if it's newer than the marker.
+func (f *Feed) MarkRead(item api.Item) {
+	if f.marker == nil || item == nil {
+		return
+	}
+	id := markerID(item)
+	if id == "" {
+		return
+	}
+	m := f.marker
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	if compareID(id, m.lastRead) <= 0 {
+		return
+	}
+	m.lastRead = id
+	if m.saving {
+		return
+	}
+	m.saving = true
+	go func() {
+		time.Sleep(markerSaveDelay)
+		f.saveMarker()
+	}()
+}
+
+func (f *Feed) saveMarker() {
+	m := f.marker
+	m.mux.Lock()
+	id := m.lastRead
+	m.saving = false
+	if id == m.saved || id == "" {
+		m.mux.Unlock()
+		return
+	}
+	m.mux.Unlock()
+	if err := f.accountClient.SetMarker(m.timeline, id); err != nil {
+		return
+	}
+	m.mux.Lock()
+	m.saved = id
+	m.mux.Unlock()
+}
+
+func (f *Feed) lastReadIndex() (int, api.Item) {
+	if f.marker == nil || f.marker.start == "" {
+		return -1, nil
+	}
+	for i, item := range f.List() {
+		id := markerID(item)
+		if id == "" || compareID(id, f.marker.start) > 0 {
+			continue
+		}
+		return i, item
+	}
+	return -1, nil
+}
+
+// LastRead returns the item you had read up to when the feed was opened. It's
+// only returned if there are newer items above it.
+func (f *Feed) LastRead() (api.Item, bool) {
+	i, item := f.lastReadIndex()
+	return item, i > 0
+}
+
+// LoadToMarker loads older items until the item you had read up to is in the
+// feed, but at most markerMaxPages pages.
+func (f *Feed) LoadToMarker() {
+	for i := 0; i < markerMaxPages; i++ {
+		if f.marker == nil || f.marker.start == "" {
+			return
+		}
+		if index, _ := f.lastReadIndex(); index >= 0 {
+			return
+		}
+		n := len(f.List())
+		f.LoadOlder()
+		if len(f.List()) == n {
+			return
+		}
+	}
+}
+
+// Unread returns the number of loaded items that are newer than the marker.
+// More is true if you haven't read the oldest loaded item either, so there
+// can be more unread items.
+func (f *Feed) Unread() (count int, more bool, ok bool) {
+	if f.marker == nil {
+		return 0, false, false
+	}
+	f.marker.mux.Lock()
+	lastRead := f.marker.lastRead
+	f.marker.mux.Unlock()
+	items := f.List()
+	for _, item := range items {
+		id := markerID(item)
+		if id != "" && compareID(id, lastRead) > 0 {
+			count++
+		}
+	}
+	return count, count > 0 && count == len(items), true
+}
+
+// SaveMarkers saves the markers that haven't been saved yet. It's used when
+// tut exits.
+func SaveMarkers() {
+	openFeedsMux.Lock()
+	var feeds []*Feed
+	for _, fs := range openFeeds {
+		feeds = append(feeds, fs...)
+	}
+	openFeedsMux.Unlock()
+	for _, f := range feeds {
+		if f.marker != nil {
+			f.saveMarker()
+		}
+	}
+}
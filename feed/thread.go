@@ -0,0 +1,242 @@
+package feed
+
+import (
+	"sort"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+)
+
+// The max number of levels a thread is indented, deeper replies are drawn
+// at this level.
+const threadMaxIndent = 6
+
+type threadState struct {
+	collapsed map[mastodon.ID]bool
+}
+
+func replyToID(s *mastodon.Status) mastodon.ID {
+	switch v := s.InReplyToID.(type) {
+	case string:
+		return mastodon.ID(v)
+	case mastodon.ID:
+		return v
+	}
+	return ""
+}
+
+func threadStatus(item api.Item) (*api.StatusItem, *mastodon.Status) {
+	s, ok := item.(*api.StatusItem)
+	if !ok {
+		return nil, nil
+	}
+	status, ok := s.Raw().(*mastodon.Status)
+	if !ok || status == nil {
+		return nil, nil
+	}
+	return s, status
+}
+
+// threadNewer loads the thread and adds the statuses that aren't in the feed
+// yet, so it can be used to look for new replies.
+func (f *Feed) threadNewer(fn apiThreadFunc, status *mastodon.Status) {
+	items, err := fn(status)
+	if err != nil {
+		return
+	}
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	existing := make(map[mastodon.ID]*api.StatusItem)
+	for _, item := range f.items {
+		if s, st := threadStatus(item); s != nil {
+			existing[st.ID] = s
+		}
+	}
+	added := false
+	for _, item := range items {
+		_, st := threadStatus(item)
+		if st == nil {
+			continue
+		}
+		if s, ok := existing[st.ID]; ok {
+			s.UpdateStatus(st)
+			continue
+		}
+		f.matchClientFilters([]api.Item{item})
+		f.items = append(f.items, item)
+		added = true
+	}
+	if added {
+		f.orderThread()
+		f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+	}
+}
+
+// addThreadReply adds status to the feed if it's a reply to a status in the
+// thread.
+func (f *Feed) addThreadReply(status *mastodon.Status) {
+	parent := replyToID(status)
+	if parent == "" {
+		return
+	}
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	found := false
+	for _, item := range f.items {
+		_, st := threadStatus(item)
+		if st == nil {
+			continue
+		}
+		if st.ID == status.ID {
+			return
+		}
+		if st.ID == parent {
+			found = true
+		}
+	}
+	if !found {
+		return
+	}
+	// The status is shared with the feed it came from.
+	s := *status
+	f.appendOlder([]api.Item{api.NewStatusItem(&s, false)})
+	f.orderThread()
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+}
+
+// addReply adds a status from a stream to the open threads of the account
+// that it replies to.
+func addReply(ac *api.AccountClient, status *mastodon.Status) {
+	if status == nil || status.Reblog != nil {
+		return
+	}
+	for _, f := range accountFeeds(ac) {
+		if f.feedType == config.Thread {
+			f.addThreadReply(status)
+		}
+	}
+}
+
+// orderThread sorts the items of a thread so replies follow the status they
+// reply to and sets their position in the tree. A reply that is the only one
+// to a status isn't indented, so a conversation between two people doesn't
+// move further to the right for each reply. Call it with itemsMux locked.
+func (f *Feed) orderThread() {
+	if f.thread == nil {
+		return
+	}
+	var statuses []*api.StatusItem
+	byID := make(map[mastodon.ID]bool)
+	for _, item := range f.items {
+		s, st := threadStatus(item)
+		if s == nil {
+			continue
+		}
+		statuses = append(statuses, s)
+		byID[st.ID] = true
+	}
+	var roots []*api.StatusItem
+	children := make(map[mastodon.ID][]*api.StatusItem)
+	for _, s := range statuses {
+		st := s.Raw().(*mastodon.Status)
+		parent := replyToID(st)
+		if parent != "" && byID[parent] {
+			children[parent] = append(children[parent], s)
+		} else {
+			roots = append(roots, s)
+		}
+	}
+	for _, c := range children {
+		sort.SliceStable(c, func(i, j int) bool {
+			return c[i].Raw().(*mastodon.Status).CreatedAt.Before(c[j].Raw().(*mastodon.Status).CreatedAt)
+		})
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		return roots[i].Raw().(*mastodon.Status).CreatedAt.Before(roots[j].Raw().(*mastodon.Status).CreatedAt)
+	})
+
+	var ordered []api.Item
+	var visit func(s *api.StatusItem, parent mastodon.ID, next mastodon.ID, cont string, glyph string, depth int) int
+	visit = func(s *api.StatusItem, parent mastodon.ID, next mastodon.ID, cont string, glyph string, depth int) int {
+		st := s.Raw().(*mastodon.Status)
+		tp := &api.ThreadPosition{
+			Prefix:      cont + glyph,
+			Parent:      parent,
+			NextSibling: next,
+			Collapsed:   f.thread.collapsed[st.ID],
+		}
+		s.SetThreadPosition(tp)
+		ordered = append(ordered, s)
+
+		replies := children[st.ID]
+		tp.Replies = len(replies)
+		childCont := cont
+		switch glyph {
+		case "├─":
+			childCont += "│ "
+		case "└─":
+			childCont += "  "
+		}
+		childDepth := depth
+		if len(replies) > 1 {
+			childDepth++
+			if childDepth > threadMaxIndent {
+				childDepth = threadMaxIndent
+				childCont = cont
+			}
+		}
+		for i, r := range replies {
+			g := ""
+			if len(replies) > 1 {
+				g = "├─"
+				if i == len(replies)-1 {
+					g = "└─"
+				}
+			}
+			n := mastodon.ID("")
+			if i < len(replies)-1 {
+				n = replies[i+1].Raw().(*mastodon.Status).ID
+			}
+			tp.Descendants += 1 + visit(r, st.ID, n, childCont, g, childDepth)
+		}
+		return tp.Descendants
+	}
+	for i, r := range roots {
+		n := mastodon.ID("")
+		if i < len(roots)-1 {
+			n = roots[i+1].Raw().(*mastodon.Status).ID
+		}
+		visit(r, "", n, "", "", 0)
+	}
+	f.items = ordered
+}
+
+// ToggleThreadCollapse hides or shows the replies to the status in item.
+func (f *Feed) ToggleThreadCollapse(item api.Item) {
+	if f.thread == nil {
+		return
+	}
+	_, st := threadStatus(item)
+	if st == nil {
+		return
+	}
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	f.thread.collapsed[st.ID] = !f.thread.collapsed[st.ID]
+	f.orderThread()
+	f.Updated(DesktopNotificationHolder{Type: DesktopNotificationNone})
+}
+
+// ThreadItem returns the item with the status id in a thread.
+func (f *Feed) ThreadItem(id mastodon.ID) (api.Item, bool) {
+	if id == "" {
+		return nil, false
+	}
+	for _, item := range f.List() {
+		if _, st := threadStatus(item); st != nil && st.ID == id {
+			return item, true
+		}
+	}
+	return nil, false
+}
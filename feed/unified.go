@@ -0,0 +1,272 @@
+package feed
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/util"
+)
+
+// unifiedState holds the feeds of the accounts a unified timeline merges. The
+// kind is home, notifications or mentions.
+type unifiedState struct {
+	mux     sync.Mutex
+	kind    string
+	sources []*Feed
+	owner   map[uint]*api.AccountClient
+	stop    chan struct{}
+	// exhausted holds the feeds that don't have any older items to load.
+	exhausted map[*Feed]bool
+}
+
+func itemTime(item api.Item) time.Time {
+	switch x := item.Raw().(type) {
+	case *mastodon.Status:
+		return x.CreatedAt
+	case *api.NotificationData:
+		return x.Item.CreatedAt
+	}
+	return time.Time{}
+}
+
+// unifiedKey is used to only show a status once if more than one account has
+// received it, either directly, as a boost or in a notification.
+func unifiedKey(item api.Item) string {
+	switch x := item.Raw().(type) {
+	case *mastodon.Status:
+		return util.StatusOrReblog(x).URI
+	case *api.NotificationData:
+		if x.Status == nil {
+			return ""
+		}
+		s, ok := x.Status.Raw().(*mastodon.Status)
+		if !ok || s.URI == "" {
+			return ""
+		}
+		return x.Item.Type + " " + x.Item.Account.URL + " " + util.StatusOrReblog(s).URI
+	}
+	return ""
+}
+
+// oldestLoaded returns when the oldest item in the feed was created.
+func (f *Feed) oldestLoaded() (time.Time, bool) {
+	f.itemsMux.RLock()
+	defer f.itemsMux.RUnlock()
+	if len(f.items) == 0 {
+		return time.Time{}, false
+	}
+	return itemTime(f.items[len(f.items)-1]), true
+}
+
+// loadOlderSource loads older items in src and returns false if there weren't
+// any.
+func loadOlderSource(src *Feed) bool {
+	src.loadingOlder.mux.Lock()
+	defer src.loadingOlder.mux.Unlock()
+	src.itemsMux.RLock()
+	before := len(src.items)
+	src.itemsMux.RUnlock()
+	src.loadOlder()
+	src.itemsMux.RLock()
+	defer src.itemsMux.RUnlock()
+	return len(src.items) > before
+}
+
+// AddAccount adds the feed of ac to a unified timeline. The account that is
+// added first wins when the same status is in more than one feed.
+func (f *Feed) AddAccount(ac *api.AccountClient) {
+	u := f.unified
+	if u == nil {
+		return
+	}
+	u.mux.Lock()
+	for _, src := range u.sources {
+		if src.accountClient == ac {
+			u.mux.Unlock()
+			return
+		}
+	}
+	var src *Feed
+	switch u.kind {
+	case "notifications":
+		src = NewNotifications(ac, f.config, f.hideBoosts, f.hideReplies)
+	case "mentions":
+		src = NewNotificationsMentions(ac, f.config)
+	default:
+		src = NewTimelineHome(ac, f.config, f.hideBoosts, f.hideReplies)
+	}
+	src.SetPollInterval(f.getPollInterval())
+	u.sources = append(u.sources, src)
+	u.mux.Unlock()
+
+	go func() {
+		for {
+			select {
+			case <-u.stop:
+				return
+			case nt := <-src.Update:
+				f.mergeUnified()
+				f.Updated(nt)
+			}
+		}
+	}()
+	src.LoadNewer()
+}
+
+// ItemClient returns the account that received item. It's only another
+// account than the one of the feed in a unified timeline.
+func (f *Feed) ItemClient(item api.Item) *api.AccountClient {
+	if f.unified == nil {
+		return f.accountClient
+	}
+	f.itemsMux.RLock()
+	defer f.itemsMux.RUnlock()
+	if ac, ok := f.unified.owner[item.ID()]; ok {
+		return ac
+	}
+	return f.accountClient
+}
+
+// UnifiedKind returns home, notifications or mentions for a unified timeline
+// and an empty string for all other feeds.
+func (f *Feed) UnifiedKind() string {
+	if f.unified == nil {
+		return ""
+	}
+	return f.unified.kind
+}
+
+// FilterType is the type of feed the filters of a status are matched against.
+// A unified timeline uses the filters of the feeds it merges.
+func (f *Feed) FilterType() config.FeedType {
+	if f.unified == nil {
+		return f.feedType
+	}
+	switch f.unified.kind {
+	case "notifications":
+		return config.Notifications
+	case "mentions":
+		return config.Mentions
+	}
+	return config.TimelineHome
+}
+
+// mergeUnified rebuilds the unified timeline from the feeds of the accounts.
+// Statuses with the same URI are only shown once. New items are unseen if
+// they're unseen in the feed of their account. Items are only shown down to
+// where all feeds have been loaded, so older items from another account can't
+// show up above them later.
+func (f *Feed) mergeUnified() {
+	u := f.unified
+	u.mux.Lock()
+	sources := append([]*Feed{}, u.sources...)
+	exhausted := make(map[*Feed]bool)
+	for src := range u.exhausted {
+		exhausted[src] = true
+	}
+	u.mux.Unlock()
+
+	var floor time.Time
+	for _, src := range sources {
+		if exhausted[src] {
+			continue
+		}
+		if t, ok := src.oldestLoaded(); ok && t.After(floor) {
+			floor = t
+		}
+	}
+
+	owner := make(map[uint]*api.AccountClient)
+	keys := make(map[string]bool)
+	unseen := make(map[uint]bool)
+	items := []api.Item{}
+	for _, src := range sources {
+		list := src.List()
+		src.itemsMux.RLock()
+		for id := range src.unseen {
+			unseen[id] = true
+		}
+		src.itemsMux.RUnlock()
+		for _, item := range list {
+			if itemTime(item).Before(floor) {
+				continue
+			}
+			if key := unifiedKey(item); key != "" {
+				if keys[key] {
+					continue
+				}
+				keys[key] = true
+			}
+			owner[item.ID()] = src.accountClient
+			items = append(items, item)
+		}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return itemTime(items[i]).After(itemTime(items[j]))
+	})
+
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	known := make(map[uint]bool)
+	for _, item := range f.items {
+		known[item.ID()] = true
+	}
+	for _, item := range items {
+		if known[item.ID()] || !unseen[item.ID()] {
+			continue
+		}
+		if f.unseen == nil {
+			f.unseen = make(map[uint]bool)
+		}
+		f.unseen[item.ID()] = true
+	}
+	f.items = items
+	u.owner = owner
+}
+
+func (f *Feed) unifiedSources() []*Feed {
+	f.unified.mux.Lock()
+	defer f.unified.mux.Unlock()
+	return append([]*Feed{}, f.unified.sources...)
+}
+
+func NewUnified(ac *api.AccountClient, cnf *config.Config, kind string, hideBoosts bool, hideReplies bool) *Feed {
+	feed := newFeed(ac, config.Unified, cnf, hideBoosts, hideReplies)
+	feed.name = kind
+	feed.unified = &unifiedState{
+		kind:      kind,
+		owner:     make(map[uint]*api.AccountClient),
+		stop:      make(chan struct{}),
+		exhausted: make(map[*Feed]bool),
+	}
+	feed.loadNewer = func() {
+		for _, src := range feed.unifiedSources() {
+			src.LoadNewer()
+		}
+	}
+	feed.loadOlder = func() {
+		for _, src := range feed.unifiedSources() {
+			feed.unified.mux.Lock()
+			done := feed.unified.exhausted[src]
+			feed.unified.mux.Unlock()
+			if done || loadOlderSource(src) {
+				continue
+			}
+			feed.unified.mux.Lock()
+			feed.unified.exhausted[src] = true
+			feed.unified.mux.Unlock()
+		}
+		feed.mergeUnified()
+	}
+	feed.close = func() {
+		close(feed.unified.stop)
+		for _, src := range feed.unifiedSources() {
+			src.Close()
+		}
+	}
+	return feed
+}
@@ -0,0 +1,55 @@
+package feed
+
+import "github.com/RasmusLindroth/tut/api"
+
+// prependNewer adds items that are newer than the items in the feed. After the
+// first load they're unseen until you scroll past them. Call it with itemsMux
+// locked.
+func (f *Feed) prependNewer(items []api.Item) {
+	f.matchClientFilters(items)
+	if f.loadedNewer {
+		if f.unseen == nil {
+			f.unseen = make(map[uint]bool)
+		}
+		for _, item := range items {
+			f.unseen[item.ID()] = true
+		}
+	}
+	f.items = append(items, f.items...)
+}
+
+// MarkSeen marks the item at index and all items below it as seen.
+func (f *Feed) MarkSeen(index int) {
+	if index < 0 {
+		return
+	}
+	list := f.filteredList()
+	f.itemsMux.Lock()
+	defer f.itemsMux.Unlock()
+	if len(f.unseen) == 0 {
+		return
+	}
+	for i := index; i < len(list); i++ {
+		delete(f.unseen, list[i].ID())
+	}
+}
+
+// Unseen returns the number of new items you haven't scrolled past yet.
+func (f *Feed) Unseen() int {
+	f.itemsMux.RLock()
+	empty := len(f.unseen) == 0
+	f.itemsMux.RUnlock()
+	if empty {
+		return 0
+	}
+	list := f.filteredList()
+	f.itemsMux.RLock()
+	defer f.itemsMux.RUnlock()
+	count := 0
+	for _, item := range list {
+		if f.unseen[item.ID()] {
+			count++
+		}
+	}
+	return count
+}
@@ -16,10 +16,10 @@ func main() {
 	util.SetTerminalTitle("tut")
 	util.MakeDirs()
 	newUser, selectedUser, cnfPath, cnfDir := ui.CliView(version)
-	accs := auth.StartAuth(newUser)
+	cnf := config.Load(cnfPath, cnfDir)
+	accs := auth.StartAuth(newUser, cnf.General.AccountsBackend)
 
 	app := tview.NewApplication()
-	cnf := config.Load(cnfPath, cnfDir)
 
 	if cnf.General.MouseSupport {
 		app.EnableMouse(true)
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/RasmusLindroth/tut/auth"
+	"github.com/RasmusLindroth/tut/util"
+)
+
+const accountsUsage = "Usage: tut accounts [list|remove <name>|rename <name> <new-name>|re-encrypt]\n"
+
+func accountsCommand(args []string) {
+	cmd := "list"
+	if len(args) > 0 {
+		cmd = args[0]
+		args = args[1:]
+	}
+	switch {
+	case cmd == "list" && len(args) == 0:
+	case cmd == "remove" && len(args) == 1:
+	case cmd == "rename" && len(args) == 2:
+	case cmd == "re-encrypt" && len(args) == 0:
+	default:
+		fmt.Print(accountsUsage)
+		os.Exit(1)
+	}
+
+	path, exists, err := util.CheckConfig("accounts.toml")
+	if err != nil {
+		fmt.Printf("Couldn't open the account file. Error: %v\n", err)
+		os.Exit(1)
+	}
+	if !exists {
+		fmt.Println("You don't have any accounts, run tut to add one.")
+		os.Exit(1)
+	}
+	accs, err := auth.GetAccounts(path)
+	if err != nil {
+		fmt.Printf("Couldn't load your accounts. Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch cmd {
+	case "list":
+		fmt.Printf("Backend: %s\n", accs.BackendName())
+		for _, acc := range accs.Accounts {
+			host := strings.TrimPrefix(acc.Server, "https://")
+			host = strings.TrimPrefix(host, "http://")
+			fmt.Printf("%s@%s\t%s\n", acc.Name, host, acc.Server)
+		}
+	case "remove":
+		err = accs.Remove(path, args[0])
+		if err == nil {
+			fmt.Printf("Removed %s\n", args[0])
+		}
+	case "rename":
+		err = accs.Rename(path, args[0], args[1])
+		if err == nil {
+			fmt.Printf("Renamed %s to %s\n", args[0], args[1])
+		}
+	case "re-encrypt":
+		err = accs.Reencrypt(path)
+		if err == nil {
+			fmt.Println("Encrypted your accounts with the new passphrase")
+		}
+	}
+	if err != nil {
+		fmt.Printf("Couldn't %s. Error: %v\n", cmd, err)
+		os.Exit(1)
+	}
+}
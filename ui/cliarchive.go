@@ -0,0 +1,255 @@
+package ui
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/util"
+	"github.com/spf13/pflag"
+)
+
+// archiveMinRemaining is how many requests that should be left before the
+// rate limit resets. When there are fewer the archive waits for the reset, so
+// tut still works while an archive is running.
+const archiveMinRemaining = 30
+
+// archiveState is saved in the archive folder. Newest is the cursor to the
+// entries added after the archive was made and Oldest is where the first run
+// continues if it stopped before it reached the end.
+type archiveState struct {
+	Newest   string `json:"newest"`
+	Oldest   string `json:"oldest"`
+	Complete bool   `json:"complete"`
+}
+
+func archiveStatePath(dir string) string {
+	return filepath.Join(dir, "archive.json")
+}
+
+func loadArchiveState(dir string) map[string]*archiveState {
+	state := make(map[string]*archiveState)
+	data, err := os.ReadFile(archiveStatePath(dir))
+	if err != nil {
+		return state
+	}
+	json.Unmarshal(data, &state)
+	return state
+}
+
+func saveArchiveState(dir string, state map[string]*archiveState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(archiveStatePath(dir), data, 0644)
+}
+
+// archivedIDs returns the ids of the toots in a JSON Lines file, so a toot is
+// never written twice.
+func archivedIDs(path string) (map[mastodon.ID]bool, error) {
+	ids := make(map[mastodon.ID]bool)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return ids, nil
+	}
+	if err != nil {
+		return ids, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var s struct {
+			ID mastodon.ID `json:"id"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &s) == nil && s.ID != "" {
+			ids[s.ID] = true
+		}
+	}
+	return ids, scanner.Err()
+}
+
+// archiveCommand runs tut archive. It has its own flags, so it's handled
+// before the flags of tut are parsed.
+func archiveCommand(args []string) {
+	fs := pflag.NewFlagSet("archive", pflag.ExitOnError)
+	bookmarks := fs.BoolP("bookmarks", "b", false, "archive your bookmarks")
+	favourites := fs.BoolP("favourites", "f", false, "archive your favourites")
+	media := fs.BoolP("media", "m", false, "download the media of the toots")
+	out := fs.StringP("out", "o", "", "save the archive in `<dir>`")
+	user := fs.StringP("user", "u", "", "archive the user named `<name>`")
+	fs.Parse(args)
+
+	if strings.TrimSpace(*out) == "" {
+		fmt.Print("Usage: tut archive [--bookmarks] [--favourites] [--media] --out <dir>\n")
+		os.Exit(1)
+	}
+	var kinds []string
+	if *bookmarks {
+		kinds = append(kinds, "bookmarks")
+	}
+	if *favourites {
+		kinds = append(kinds, "favourites")
+	}
+	if len(kinds) == 0 {
+		kinds = api.ArchiveKinds
+	}
+	dir, err := util.GetAbsPath(strings.TrimSpace(*out))
+	if err != nil {
+		fmt.Printf("Couldn't find the folder. Error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		fmt.Printf("Couldn't create the folder. Error: %v\n", err)
+		os.Exit(1)
+	}
+	ac, err := cliAccountClient(strings.TrimSpace(*user))
+	if err != nil {
+		fmt.Printf("Couldn't login. Error: %v\n", err)
+		os.Exit(1)
+	}
+	state := loadArchiveState(dir)
+	for _, kind := range kinds {
+		if _, ok := state[kind]; !ok {
+			state[kind] = &archiveState{}
+		}
+		err := archiveKind(ac, kind, dir, state, *media)
+		if err != nil {
+			fmt.Printf("\nThe archive of %s stopped. Error: %v\n", kind, err)
+			fmt.Printf("Run the same command again to resume.\n")
+			os.Exit(1)
+		}
+	}
+}
+
+func archiveKind(ac *api.AccountClient, kind string, dir string, state map[string]*archiveState, media bool) error {
+	path := filepath.Join(dir, kind+".jsonl")
+	ids, err := archivedIDs(path)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	s := state[kind]
+	added := 0
+	write := func(entries []api.ArchiveEntry) error {
+		for _, e := range entries {
+			if ids[e.Status.ID] {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := json.Compact(&buf, e.Raw); err != nil {
+				return err
+			}
+			buf.WriteByte('\n')
+			if _, err := f.Write(buf.Bytes()); err != nil {
+				return err
+			}
+			ids[e.Status.ID] = true
+			added++
+			if media {
+				archiveMedia(e.Status, dir)
+			}
+		}
+		fmt.Printf("\rArchived %d new %s", added, kind)
+		return nil
+	}
+	fetch := func(pg *mastodon.Pagination) ([]api.ArchiveEntry, error) {
+		if d := ac.RateLimitWait(archiveMinRemaining); d > 0 {
+			fmt.Printf("\rWaiting %s for the rate limit to reset", d.Round(time.Second))
+			select {
+			case <-time.After(d):
+			case <-ac.Context().Done():
+				return nil, ac.Context().Err()
+			}
+		}
+		return ac.GetArchivePage(kind, pg)
+	}
+
+	if s.Oldest != "" {
+		fmt.Printf("Resuming the archive of %s\n", kind)
+	}
+	// There was nothing to archive the last time, so there's no cursor for
+	// the new ones. Start from the top again, the archived ones are skipped.
+	if s.Complete && s.Newest == "" {
+		s.Complete = false
+	}
+	for !s.Complete {
+		pg := &mastodon.Pagination{MaxID: mastodon.ID(s.Oldest)}
+		first := s.Oldest == ""
+		entries, err := fetch(pg)
+		if err != nil {
+			return err
+		}
+		if first {
+			s.Newest = string(pg.MinID)
+		}
+		if err := write(entries); err != nil {
+			return err
+		}
+		s.Oldest = string(pg.MaxID)
+		if len(entries) == 0 || pg.MaxID == "" {
+			s.Complete = true
+			s.Oldest = ""
+		}
+		if err := saveArchiveState(dir, state); err != nil {
+			return err
+		}
+	}
+	for s.Newest != "" {
+		pg := &mastodon.Pagination{MinID: mastodon.ID(s.Newest)}
+		entries, err := fetch(pg)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 || pg.MinID == "" {
+			break
+		}
+		if err := write(entries); err != nil {
+			return err
+		}
+		s.Newest = string(pg.MinID)
+		if err := saveArchiveState(dir, state); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("\rDone. Archived %d new %s to %s\n", added, kind, path)
+	return nil
+}
+
+// archiveMedia downloads the media of status to the media folder of the
+// archive. A file that couldn't be downloaded is skipped.
+func archiveMedia(status *mastodon.Status, dir string) {
+	status = util.StatusOrReblog(status)
+	if len(status.MediaAttachments) == 0 {
+		return
+	}
+	mediaDir := filepath.Join(dir, "media")
+	if err := os.MkdirAll(mediaDir, 0755); err != nil {
+		fmt.Printf("\rCouldn't create the media folder. Error: %v\n", err)
+		return
+	}
+	for i, a := range status.MediaAttachments {
+		if a.URL == "" {
+			continue
+		}
+		p := filepath.Join(mediaDir, util.MediaFilename(status, i, a))
+		if _, err := os.Stat(p); err == nil {
+			continue
+		}
+		if err := util.DownloadFile(a.URL, p); err != nil {
+			fmt.Printf("\rCouldn't download media of %s. Error: %v\n", status.URL, err)
+		}
+	}
+}
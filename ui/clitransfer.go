@@ -0,0 +1,265 @@
+package ui
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/auth"
+	"github.com/RasmusLindroth/tut/util"
+	"golang.org/x/exp/slices"
+)
+
+// importMaxFailed is how many rows in a row that can fail before an import
+// stops. Failed rows are retried when the import is resumed.
+const importMaxFailed = 5
+
+// transferProgress is saved next to the CSV file so an export or import that
+// stops half way can be resumed by running the same command again.
+type transferProgress struct {
+	Kind   string `json:"kind"`
+	Cursor string `json:"cursor"`
+	Rows   int    `json:"rows"`
+	// Offset is the size of the exported file when the progress was saved,
+	// rows written after it are written again on resume.
+	Offset int64 `json:"offset,omitempty"`
+	// Failed are the rows that couldn't be imported.
+	Failed []int `json:"failed,omitempty"`
+}
+
+func progressPath(path string) string {
+	return path + ".progress"
+}
+
+func loadTransferProgress(path string, kind string) (*transferProgress, bool) {
+	p := &transferProgress{Kind: kind}
+	data, err := os.ReadFile(progressPath(path))
+	if err != nil {
+		return p, false
+	}
+	var saved transferProgress
+	if err := json.Unmarshal(data, &saved); err != nil || saved.Kind != kind {
+		return p, false
+	}
+	return &saved, true
+}
+
+func (p *transferProgress) save(path string) error {
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(progressPath(path), data, 0600)
+}
+
+func cliAccountClient(selectedUser string) (*api.AccountClient, error) {
+	accs := auth.StartAuth(false, "")
+	var acc auth.Account
+	switch {
+	case selectedUser != "":
+		var found bool
+		acc, found = findAccount(accs, selectedUser)
+		if !found {
+			return nil, fmt.Errorf("couldn't find a user named %s", selectedUser)
+		}
+	case len(accs.Accounts) == 1:
+		acc = accs.Accounts[0]
+	default:
+		return nil, errors.New("you have more than one account, choose one with --user <name>")
+	}
+	client := mastodon.NewClient(&mastodon.Config{
+		Server:       acc.Server,
+		ClientID:     acc.ClientID,
+		ClientSecret: acc.ClientSecret,
+		AccessToken:  acc.AccessToken,
+	})
+	me, err := client.GetAccountCurrentUser(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	ac := &api.AccountClient{
+		Me:      me,
+		Client:  client,
+		Streams: make(map[string]*api.Stream),
+	}
+	ac.SetBatch(cliContext())
+	return ac, nil
+}
+
+// cliContext is cancelled on the first ^C, so a command can stop and save how
+// far it got. The second ^C stops tut right away.
+func cliContext() context.Context {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	return ctx
+}
+
+// transferCommand runs tut export <kind> <file> and tut import <kind> <file>.
+func transferCommand(cmd string, args []string, selectedUser string) {
+	if len(args) != 2 {
+		fmt.Printf("Usage: tut %s <%s> <file>\n", cmd, strings.Join(api.TransferKinds, "|"))
+		os.Exit(1)
+	}
+	kind := args[0]
+	path, err := util.GetAbsPath(args[1])
+	if err != nil {
+		fmt.Printf("Couldn't find the file. Error: %v\n", err)
+		os.Exit(1)
+	}
+	ac, err := cliAccountClient(selectedUser)
+	if err != nil {
+		fmt.Printf("Couldn't login. Error: %v\n", err)
+		os.Exit(1)
+	}
+	if cmd == "export" {
+		err = exportCSV(ac, kind, path)
+	} else {
+		err = importCSV(ac, kind, path)
+	}
+	if err != nil {
+		fmt.Printf("\nThe %s stopped. Error: %v\n", cmd, err)
+		if _, serr := os.Stat(progressPath(path)); serr == nil {
+			fmt.Printf("Run the same command again to resume.\n")
+		}
+		os.Exit(1)
+	}
+}
+
+func exportCSV(ac *api.AccountClient, kind string, path string) error {
+	if err := api.ValidTransferKind(kind); err != nil {
+		return err
+	}
+	p, resume := loadTransferProgress(path, kind)
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume {
+		flags = os.O_CREATE | os.O_WRONLY
+		fmt.Printf("Resuming the export of %s after %d rows\n", kind, p.Rows)
+	}
+	f, err := os.OpenFile(path, flags, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if resume {
+		if err := f.Truncate(p.Offset); err != nil {
+			return err
+		}
+		if _, err := f.Seek(p.Offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+	w := csv.NewWriter(f)
+	if h := api.TransferHeader(kind); h != nil && !resume {
+		w.Write(h)
+	}
+	for {
+		rows, next, err := ac.ExportPage(kind, p.Cursor)
+		if err != nil {
+			w.Flush()
+			return err
+		}
+		if err := w.WriteAll(rows); err != nil {
+			return err
+		}
+		p.Offset, err = f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		p.Rows += len(rows)
+		p.Cursor = next
+		fmt.Printf("\rExported %d rows", p.Rows)
+		if next == "" {
+			break
+		}
+		if err := p.save(path); err != nil {
+			return err
+		}
+	}
+	os.Remove(progressPath(path))
+	fmt.Printf("\nDone. Exported %s to %s\n", kind, path)
+	return nil
+}
+
+func importCSV(ac *api.AccountClient, kind string, path string) error {
+	imp, err := ac.NewImporter(kind)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	f.Close()
+	if err != nil {
+		return err
+	}
+	p, resume := loadTransferProgress(path, kind)
+	if resume {
+		fmt.Printf("Resuming the import of %s at row %d of %d\n", kind, p.Rows+1, len(rows))
+		if len(p.Failed) > 0 {
+			fmt.Printf("Retrying %d rows that failed\n", len(p.Failed))
+		}
+	}
+	// Rows that failed before are tried first, they stay in p.Failed until
+	// they're imported.
+	start := p.Rows
+	todo := append([]int{}, p.Failed...)
+	for i := start; i < len(rows); i++ {
+		todo = append(todo, i)
+	}
+	inRow := 0
+	for _, i := range todo {
+		if i >= len(rows) || (i == 0 && imp.IsHeader(rows[i])) {
+			continue
+		}
+		err := imp.Import(rows[i])
+		k := slices.Index(p.Failed, i)
+		if err != nil {
+			// Rows that keep failing on every retry shouldn't stop the
+			// rest of the import.
+			if i >= start {
+				inRow++
+			}
+			if k < 0 {
+				p.Failed = append(p.Failed, i)
+			}
+			fmt.Printf("\rRow %d: %v\n", i+1, err)
+		} else {
+			inRow = 0
+			if k >= 0 {
+				p.Failed = slices.Delete(p.Failed, k, k+1)
+			}
+		}
+		if i >= start {
+			p.Rows = i + 1
+		}
+		if err := p.save(path); err != nil {
+			return err
+		}
+		if inRow >= importMaxFailed {
+			return fmt.Errorf("%d rows in a row failed", inRow)
+		}
+		fmt.Printf("\rImported %d of %d rows", p.Rows, len(rows))
+	}
+	if len(p.Failed) > 0 {
+		fmt.Printf("\nDone. %d rows couldn't be imported, run the same command again to retry them\n", len(p.Failed))
+		return nil
+	}
+	os.Remove(progressPath(path))
+	fmt.Printf("\nDone. All rows were imported\n")
+	return nil
+}
@@ -18,6 +18,10 @@ func CliView(version string) (newUser bool, selectedUser string, confPath string
 	user := pflag.StringP("user", "u", "", "login directly to user named `<name>`")
 	cnf := pflag.StringP("config", "c", "", "load config.toml from `<path>`")
 	cnfDir := pflag.StringP("config-dir", "d", "", "load all config from `<path>`")
+	if len(os.Args) > 1 && os.Args[1] == "archive" {
+		archiveCommand(os.Args[2:])
+		os.Exit(0)
+	}
 	pflag.Parse()
 
 	if len(os.Args) > 1 {
@@ -27,6 +31,16 @@ func CliView(version string) (newUser bool, selectedUser string, confPath string
 			os.Exit(0)
 		}
 	}
+	if args := pflag.Args(); len(args) > 0 {
+		switch args[0] {
+		case "export", "import":
+			transferCommand(args[0], args[1:], strings.TrimSpace(*user))
+			os.Exit(0)
+		case "accounts":
+			accountsCommand(args[1:])
+			os.Exit(0)
+		}
+	}
 	if nu != nil && *nu {
 		newUser = true
 	}
@@ -69,7 +83,11 @@ func CliView(version string) (newUser bool, selectedUser string, confPath string
 		fmt.Print("\tTo run the program you just have to write tut\n\n")
 
 		fmt.Print("Commands:\n")
-		fmt.Print("\texample-config - creates the default configuration file in the current directory and names it ./config.example.toml\n\n")
+		fmt.Print("\texample-config - creates the default configuration file in the current directory and names it ./config.example.toml\n")
+		fmt.Print("\texport <kind> <file> - exports following, blocks, mutes, domain-blocks, lists or bookmarks to a CSV file\n")
+		fmt.Print("\timport <kind> <file> - imports a CSV file exported by tut or Mastodon. Run it again to resume if it stops\n")
+		fmt.Print("\tarchive [--bookmarks] [--favourites] [--media] --out <dir> - saves your bookmarks and favourites as JSON Lines. Run it again to add new ones\n")
+		fmt.Print("\taccounts [list|remove <name>|rename <name> <new-name>|re-encrypt] - manages the accounts you've added to tut\n\n")
 
 		fmt.Print("Flags:\n")
 		fmt.Print("\t-h  --help             prints this message\n")
@@ -1,6 +1,7 @@
 package ui
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/RasmusLindroth/tut/config"
@@ -64,6 +65,7 @@ func (c *CmdBar) DoneFunc(key tcell.Key) {
 		fallthrough
 	case ":quit":
 		c.tutView.tut.App.Stop()
+		c.tutView.CleanExit(0)
 	case ":compose":
 		c.tutView.ComposeCommand()
 		c.ClearInput()
@@ -97,11 +99,28 @@ func (c *CmdBar) DoneFunc(key tcell.Key) {
 	case ":history":
 		c.tutView.HistoryCommand()
 		c.Back()
+	case ":export":
+		c.Back()
+		c.tutView.ExportCommand(parts[1:])
+	case ":act-as":
+		c.Back()
+		c.tutView.ActAsCommand(parts[1:])
 	case ":newer":
 		c.tutView.LoadNewerCommand()
 		c.Back()
 	case ":login":
-		c.tutView.LoginCommand()
+		server := ""
+		if len(parts) > 1 {
+			server = strings.TrimSpace(parts[1])
+		}
+		c.tutView.LoginCommand(server)
+		c.Back()
+	case ":login-code":
+		code := ""
+		if len(parts) > 1 {
+			code = strings.TrimSpace(parts[1])
+		}
+		c.tutView.LoginCodeCommand(code)
 		c.Back()
 	case ":next-acct":
 		c.tutView.NextAcct()
@@ -234,6 +253,54 @@ func (c *CmdBar) DoneFunc(key tcell.Key) {
 	case ":tags":
 		c.tutView.TagsCommand()
 		c.Back()
+	case ":trending":
+		kind := ""
+		if len(parts) > 1 {
+			kind = strings.TrimSpace(parts[1])
+		}
+		c.tutView.TrendingCommand(kind)
+		c.Back()
+	case ":unified":
+		kind := ""
+		if len(parts) > 1 {
+			kind = strings.TrimSpace(parts[1])
+		}
+		c.tutView.UnifiedCommand(kind)
+		c.Back()
+	case ":drafts":
+		c.tutView.DraftsCommand()
+		c.Back()
+	case ":scheduled":
+		c.tutView.ScheduledCommand()
+		c.Back()
+	case ":domain-blocks":
+		c.tutView.DomainBlocksCommand()
+		c.Back()
+	case ":filters":
+		c.tutView.FiltersCommand()
+		c.Back()
+	case ":new-filter":
+		c.Back()
+		c.tutView.NewFilterCommand(strings.TrimSpace(strings.Join(parts[1:], " ")))
+	case ":new-list":
+		c.Back()
+		c.tutView.NewListCommand(strings.TrimSpace(strings.Join(parts[1:], " ")))
+	case ":reschedule":
+		if len(parts) < 2 {
+			break
+		}
+		c.tutView.RescheduleCommand(strings.Join(parts[1:], " "))
+		c.Back()
+	case ":search":
+		if len(parts) < 2 {
+			break
+		}
+		search := strings.TrimSpace(strings.Join(parts[1:], " "))
+		if len(search) == 0 {
+			break
+		}
+		c.tutView.SearchCommand(search)
+		c.Back()
 	case ":pane":
 		if len(parts) < 2 {
 			break
@@ -294,7 +361,7 @@ func (c *CmdBar) DoneFunc(key tcell.Key) {
 
 func (c *CmdBar) Autocomplete(curr string) []string {
 	var entries []string
-	words := strings.Split(":blocking,:boosts,:bookmarks,:clear-notifications,:clear-temp,:close-pane,:compose,:favorites,:favorited,:follow-tag,:followers,:following,:help,:h,:history,:move-pane,:next-acct,:lists,:list-placement,:list-split,:login,:muting,:newer,:preferences,:prev-acct,:profile,:proportions,:refetch,:requests,:saved,:stick-to-top,:tag,:timeline,:tl,:unfollow-tag,:user,:pane,:quit,:q", ",")
+	words := strings.Split(":act-as,:blocking,:boosts,:bookmarks,:clear-notifications,:clear-temp,:close-pane,:compose,:domain-blocks,:drafts,:export,:favorites,:favorited,:filters,:follow-tag,:followers,:following,:help,:h,:history,:move-pane,:next-acct,:lists,:list-placement,:list-split,:login,:login-code,:muting,:new-filter,:new-list,:newer,:preferences,:prev-acct,:profile,:proportions,:refetch,:requests,:reschedule,:saved,:scheduled,:search,:stick-to-top,:tag,:timeline,:tl,:trending,:unified,:unfollow-tag,:user,:pane,:quit,:q", ",")
 	if curr == "" {
 		return entries
 	}
@@ -305,6 +372,23 @@ func (c *CmdBar) Autocomplete(curr string) []string {
 	if len(curr) > 8 && curr[:9] == ":timeline" {
 		words = strings.Split(":timeline home,:timeline notifications,:timeline local,:timeline federated,:timeline direct,:timeline mentions,:timeline favorited,:timeline special-all,:timeline special-boosts,:timeline special-replies", ",")
 	}
+	if len(curr) > 8 && curr[:9] == ":trending" {
+		words = strings.Split(":trending statuses,:trending tags,:trending links", ",")
+	}
+	if len(curr) > 7 && curr[:8] == ":unified" {
+		words = strings.Split(":unified home,:unified notifications,:unified mentions", ",")
+	}
+	if len(curr) > 6 && curr[:7] == ":export" {
+		words = strings.Split(":export markdown,:export html,:export json,:export thread markdown,:export thread html,:export thread json", ",")
+	}
+	if strings.HasPrefix(curr, ":act-as ") {
+		words = nil
+		for _, t := range c.tutView.otherAccounts() {
+			for _, action := range actAsActions {
+				words = append(words, fmt.Sprintf(":act-as %s %s", t.accountName(), action))
+			}
+		}
+	}
 	if len(curr) > 14 && curr[:15] == ":list-placement" {
 		words = strings.Split(":list-placement top,:list-placement right,:list-placement bottom,:list-placement left", ",")
 	}
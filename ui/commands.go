@@ -1,14 +1,20 @@
 package ui
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/auth"
 	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/feed"
 	"github.com/RasmusLindroth/tut/util"
+	"golang.org/x/exp/slices"
 )
 
 func (tv *TutView) ComposeCommand() {
@@ -145,6 +151,95 @@ func (tv *TutView) TagCommand(tag string) {
 		tv.tut.Config.General.CommandsInNewPane)
 }
 
+func (tv *TutView) SearchCommand(search string) {
+	statusesOnly, err := api.ParseSearch(search)
+	if err != nil {
+		tv.ShowError(fmt.Sprintf("Couldn't search. Error: %v\n", err))
+		return
+	}
+	tv.Timeline.AddFeed(
+		NewSearchFeed(tv, config.NewTimeline(config.Timeline{
+			FeedType:  config.Search,
+			Subaction: search,
+		}), statusesOnly),
+		tv.tut.Config.General.CommandsInNewPane)
+}
+
+func (tv *TutView) ScheduledCommand() {
+	tv.Timeline.AddFeed(
+		NewScheduledFeed(tv, config.NewTimeline(config.Timeline{
+			FeedType: config.Scheduled,
+		})),
+		tv.tut.Config.General.CommandsInNewPane)
+}
+
+func (tv *TutView) DraftsCommand() {
+	tv.Timeline.AddFeed(
+		NewDraftsFeed(tv, config.NewTimeline(config.Timeline{
+			FeedType: config.Drafts,
+		})),
+		tv.tut.Config.General.CommandsInNewPane)
+}
+
+func (tv *TutView) FiltersCommand() {
+	tv.Timeline.AddFeed(
+		NewFiltersFeed(tv, config.NewTimeline(config.Timeline{
+			FeedType: config.Filters,
+		})),
+		tv.tut.Config.General.CommandsInNewPane)
+}
+
+func (tv *TutView) DomainBlocksCommand() {
+	tv.Timeline.AddFeed(
+		NewDomainBlocksFeed(tv, config.NewTimeline(config.Timeline{
+			FeedType: config.DomainBlocks,
+		})),
+		tv.tut.Config.General.CommandsInNewPane)
+}
+
+func (tv *TutView) NewFilterCommand(title string) {
+	tv.InitFilter(&api.Filter{
+		Title:        title,
+		Context:      []string{"home", "notifications", "public", "thread"},
+		FilterAction: "warn",
+	})
+}
+
+func (tv *TutView) NewListCommand(title string) {
+	if title == "" {
+		tv.EditListTitle(nil)
+		return
+	}
+	tv.CreateList(title)
+}
+
+func (tv *TutView) RescheduleCommand(when string) {
+	item, itemErr := tv.GetCurrentItem()
+	if itemErr != nil {
+		return
+	}
+	if item.Type() != api.ScheduledType {
+		return
+	}
+	s := item.Raw().(*api.ScheduledStatus)
+	t, err := util.ParseSchedule(when, time.Now())
+	if err != nil {
+		tv.ShowError(fmt.Sprintf("Couldn't reschedule toot. Error: %v\n", err))
+		return
+	}
+	if t == nil {
+		return
+	}
+	ns, err := tv.tut.Client.RescheduleStatus(s, *t)
+	if err != nil {
+		tv.ShowError(fmt.Sprintf("Couldn't reschedule toot. Error: %v\n", err))
+		return
+	}
+	*s = *ns
+	tv.GetCurrentFeed().Data.Updated(feed.DesktopNotificationHolder{Type: feed.DesktopNotificationNone})
+	tv.RedrawContent()
+}
+
 func (tv *TutView) TagsCommand() {
 	tv.Timeline.AddFeed(
 		NewTagsFeed(tv, config.NewTimeline(config.Timeline{
@@ -153,6 +248,44 @@ func (tv *TutView) TagsCommand() {
 		tv.tut.Config.General.CommandsInNewPane)
 }
 
+func (tv *TutView) TrendingCommand(kind string) {
+	tl := config.NewTimeline(config.Timeline{})
+	var nf *Feed
+	switch kind {
+	case "", "statuses":
+		tl.FeedType = config.TrendingStatuses
+		nf = NewTrendingStatusesFeed(tv, tl)
+	case "tags":
+		tl.FeedType = config.TrendingTags
+		nf = NewTrendingTagsFeed(tv, tl)
+	case "links":
+		tl.FeedType = config.TrendingLinks
+		nf = NewTrendingLinksFeed(tv, tl)
+	default:
+		tv.ShowError(fmt.Sprintf("Unknown trend %s, use one of %s", kind, strings.Join(api.TrendingKinds, ", ")))
+		return
+	}
+	tv.Timeline.AddFeed(nf, tv.tut.Config.General.CommandsInNewPane)
+}
+
+func (tv *TutView) UnifiedCommand(kind string) {
+	if kind == "" {
+		kind = "home"
+	}
+	switch kind {
+	case "home", "notifications", "mentions":
+	default:
+		tv.ShowError(fmt.Sprintf("Unknown unified timeline %s, use one of home, notifications, mentions", kind))
+		return
+	}
+	tv.Timeline.AddFeed(
+		NewUnifiedFeed(tv, config.NewTimeline(config.Timeline{
+			FeedType:  config.Unified,
+			Subaction: kind,
+		})),
+		tv.tut.Config.General.CommandsInNewPane)
+}
+
 func (tv *TutView) TagFollowCommand(tag string) {
 	err := tv.tut.Client.FollowTag(tag)
 	if err != nil {
@@ -283,6 +416,67 @@ func (tv *TutView) HistoryCommand() {
 		tv.tut.Config.General.CommandsInNewPane)
 }
 
+func (tv *TutView) ExportCommand(args []string) {
+	thread := false
+	if len(args) > 0 && args[0] == "thread" {
+		thread = true
+		args = args[1:]
+	}
+	if len(args) < 2 {
+		tv.ShowError(fmt.Sprintf("Usage: :export [thread] <%s> <path>", strings.Join(util.ExportFormats, "|")))
+		return
+	}
+	format := strings.ToLower(args[0])
+	path, err := util.GetAbsPath(strings.Join(args[1:], " "))
+	if err != nil {
+		tv.ShowError(fmt.Sprintf("Couldn't export. Error: %v\n", err))
+		return
+	}
+	item, itemErr := tv.GetCurrentItem()
+	if itemErr != nil {
+		return
+	}
+	var status *mastodon.Status
+	switch item.Type() {
+	case api.StatusType:
+		status = item.Raw().(*mastodon.Status)
+	case api.NotificationType:
+		nd := item.Raw().(*api.NotificationData)
+		if nd.Status != nil {
+			status = nd.Status.Raw().(*mastodon.Status)
+		}
+	}
+	if status == nil {
+		tv.ShowError("You can only export toots")
+		return
+	}
+	tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Exporting to %s", path))
+	go func() {
+		statuses := []*mastodon.Status{status}
+		if thread {
+			items, err := tv.tut.Client.GetThread(util.StatusOrReblog(status))
+			if err != nil {
+				tv.tut.App.QueueUpdateDraw(func() {
+					tv.ShowError(fmt.Sprintf("Couldn't load thread. Error: %v\n", err))
+				})
+				return
+			}
+			statuses = []*mastodon.Status{}
+			for _, it := range items {
+				statuses = append(statuses, it.Raw().(*mastodon.Status))
+			}
+		}
+		err := util.ExportStatuses(statuses, format, path)
+		tv.tut.App.QueueUpdateDraw(func() {
+			if err != nil {
+				tv.ShowError(fmt.Sprintf("Couldn't export. Error: %v\n", err))
+				return
+			}
+			tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Exported %d toots to %s", len(statuses), path))
+		})
+	}()
+}
+
 func (tv *TutView) ProfileCommand() {
 	item, err := tv.tut.Client.GetUserByID(tv.tut.Client.Me.ID)
 	if err != nil {
@@ -331,8 +525,157 @@ func (tv *TutView) LoadNewerCommand() {
 	f.LoadNewer(true)
 }
 
-func (tv *TutView) LoginCommand() {
-	NewTutView("")
+var actAsActions = []string{"favorite", "boost", "bookmark", "reply"}
+
+// ActAsCommand finds the selected toot on the instance of another account
+// you are logged in to and favorites, boosts, saves or replies to it as that
+// account.
+func (tv *TutView) ActAsCommand(args []string) {
+	usage := fmt.Sprintf("Usage: :act-as <account> <%s>", strings.Join(actAsActions, "|"))
+	if len(args) != 2 {
+		tv.ShowError(usage)
+		return
+	}
+	var other *TutView
+	for _, t := range tv.otherAccounts() {
+		if t.tut.Client.Me.Acct == args[0] || t.accountName() == args[0] {
+			other = t
+			break
+		}
+	}
+	if other == nil {
+		tv.ShowError(fmt.Sprintf("You aren't logged in to another account named %s", args[0]))
+		return
+	}
+	action := strings.ToLower(args[1])
+	if !slices.Contains(actAsActions, action) {
+		tv.ShowError(usage)
+		return
+	}
+	item, itemErr := tv.GetCurrentItem()
+	if itemErr != nil {
+		return
+	}
+	var status *mastodon.Status
+	switch item.Type() {
+	case api.StatusType:
+		status = item.Raw().(*mastodon.Status)
+	case api.NotificationType:
+		nd := item.Raw().(*api.NotificationData)
+		if nd.Status != nil {
+			status = nd.Status.Raw().(*mastodon.Status)
+		}
+	}
+	if status == nil {
+		tv.ShowError("You can only act on toots as another account")
+		return
+	}
+	uri := util.StatusOrReblog(status).URI
+	name := other.accountName()
+	tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Finding the toot as %s", name))
+	go func() {
+		done := ""
+		s, err := other.tut.Client.ResolveStatus(uri)
+		if err == nil {
+			switch action {
+			case "favorite":
+				done = "Favorited"
+				_, err = other.tut.Client.Favorite(s)
+			case "boost":
+				done = "Boosted"
+				_, err = other.tut.Client.Boost(s)
+			case "bookmark":
+				done = "Saved"
+				_, err = other.tut.Client.Bookmark(s)
+			}
+		}
+		tv.tut.App.QueueUpdateDraw(func() {
+			if err != nil {
+				tv.ShowError(fmt.Sprintf("Couldn't %s the toot as %s. Error: %v\n", action, name, err))
+				return
+			}
+			if action == "reply" {
+				TutViews.Focus(other)
+				other.InitPost(s, nil)
+				return
+			}
+			tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("%s the toot as %s", done, name))
+		})
+	}()
+}
+
+// LoginCommand logs in to one more of your accounts. With an instance you log
+// in to a new account in the browser instead.
+func (tv *TutView) LoginCommand(server string) {
+	if server == "" {
+		NewTutView("")
+		return
+	}
+	tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Connecting to %s", server))
+	go func() {
+		server, err := auth.CheckServer(server)
+		var login *auth.Login
+		if err == nil {
+			login, err = auth.NewLogin(server, !auth.Headless())
+		}
+		if err != nil {
+			tv.tut.App.QueueUpdateDraw(func() {
+				tv.ShowError(fmt.Sprintf("Couldn't connect to %s. Error: %v\n", server, err))
+			})
+			return
+		}
+		util.OpenURL(login.AuthURL)
+		if !login.Loopback() {
+			tv.tut.App.QueueUpdateDraw(func() {
+				tv.pendingLogin = login
+				copyToClipboard(login.AuthURL)
+				tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Authorize tut at %s and then run :login-code <code>", login.AuthURL))
+			})
+			return
+		}
+		defer login.Close()
+		tv.tut.App.QueueUpdateDraw(func() {
+			tv.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Authorize tut in your browser, tut continues by itself when you're done. %s", login.AuthURL))
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), auth.LoopbackTimeout)
+		defer cancel()
+		client, err := login.Wait(ctx)
+		tv.finishLogin(client, err)
+	}()
+}
+
+// LoginCodeCommand finishes a login started with :login <instance> when tut
+// couldn't be reached by the browser.
+func (tv *TutView) LoginCodeCommand(code string) {
+	login := tv.pendingLogin
+	if login == nil {
+		tv.ShowError("Start the login with :login <instance> first")
+		return
+	}
+	if code == "" {
+		tv.ShowError("Usage: :login-code <code>")
+		return
+	}
+	go func() {
+		client, err := login.Exchange(context.Background(), code)
+		tv.finishLogin(client, err)
+	}()
+}
+
+// finishLogin saves the new account and opens it in a new view.
+func (tv *TutView) finishLogin(client *mastodon.Client, err error) {
+	tv.tut.App.QueueUpdateDraw(func() {
+		var acc auth.Account
+		if err == nil {
+			acc, err = auth.SaveAccount(Accounts, client)
+		}
+		if err != nil {
+			tv.ShowError(fmt.Sprintf("Couldn't log in. Error: %v\n", err))
+			return
+		}
+		tv.pendingLogin = nil
+		newTutViewAccount(acc)
+	})
 }
 
 func (tv *TutView) NextAcct() {
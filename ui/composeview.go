@@ -11,22 +11,28 @@ import (
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
 	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/feed"
 	"github.com/RasmusLindroth/tut/util"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"github.com/rivo/uniseg"
+	"golang.org/x/exp/slices"
 	"mvdan.cc/xurls/v2"
 )
 
 type msgToot struct {
-	ID            mastodon.ID
-	Text          string
-	Reply         *mastodon.Status
+	ID    mastodon.ID
+	Text  string
+	Reply *mastodon.Status
+	// ReplyID is the toot it replies to when Reply couldn't be loaded.
+	ReplyID       mastodon.ID
 	Edit          *mastodon.Status
 	MediaIDs      []mastodon.ID
 	Sensitive     bool
 	CWText        string
 	ScheduledAt   *time.Time
+	Scheduled     *api.ScheduledStatus
+	Draft         *util.Draft
 	QuoteIncluded bool
 	Visibility    string
 	Language      string
@@ -44,8 +50,11 @@ type ComposeView struct {
 	controls     *tview.Flex
 	visibility   *tview.DropDown
 	lang         *tview.DropDown
+	schedule     *tview.InputField
 	media        *MediaList
+	crossPost    *CrossPostList
 	msg          *msgToot
+	draftBase    string
 }
 
 var visibilities = map[string]int{
@@ -73,7 +82,9 @@ func NewComposeView(tv *TutView) *ComposeView {
 		info:         NewTextView(tv.tut.Config),
 		visibility:   NewDropDown(tv.tut.Config),
 		lang:         NewDropDown(tv.tut.Config),
+		schedule:     NewInputField(tv.tut.Config),
 		media:        NewMediaList(tv),
+		crossPost:    NewCrossPostList(tv),
 	}
 	cv.content.SetDynamicColors(true)
 	cv.View = newComposeUI(cv)
@@ -85,16 +96,19 @@ func newComposeUI(cv *ComposeView) *tview.Flex {
 	if cv.tutView.tut.Config.General.TerminalTitle < 2 {
 		r.AddItem(cv.tutView.Shared.Top.View, 1, 0, false)
 	}
+	side := tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(cv.visibility, 1, 0, false).
+		AddItem(cv.lang, 1, 0, false).
+		AddItem(cv.schedule, 1, 0, false).
+		AddItem(cv.info, 5, 0, false).
+		AddItem(cv.media.View, 0, 1, false).
+		AddItem(cv.crossPost.View, 0, 1, false)
 	if !cv.tutView.tut.Config.General.UseInternalEditor {
 		r.AddItem(tview.NewFlex().SetDirection(tview.FlexColumn).
 			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
 				AddItem(cv.content, 0, 2, false), 0, 2, false).
 			AddItem(tview.NewBox(), 2, 0, false).
-			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
-				AddItem(cv.visibility, 1, 0, false).
-				AddItem(cv.lang, 1, 0, false).
-				AddItem(cv.info, 5, 0, false).
-				AddItem(cv.media.View, 0, 1, false), 0, 1, false), 0, 1, false).
+			AddItem(side, 0, 1, false), 0, 1, false).
 			AddItem(cv.input.View, 1, 0, false).
 			AddItem(cv.controls, 1, 0, false).
 			AddItem(cv.tutView.Shared.Bottom.View, 2, 0, false)
@@ -111,11 +125,7 @@ func newComposeUI(cv *ComposeView) *tview.Flex {
 				AddItem(txtTwo, 1, 0, false).
 				AddItem(cv.textAreaMain, 0, 2, false), 0, 2, false).
 			AddItem(tview.NewBox(), 2, 0, false).
-			AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
-				AddItem(cv.visibility, 1, 0, false).
-				AddItem(cv.lang, 1, 0, false).
-				AddItem(cv.info, 5, 0, false).
-				AddItem(cv.media.View, 0, 1, false), 0, 1, false), 0, 1, false).
+			AddItem(side, 0, 1, false), 0, 1, false).
 			AddItem(cv.input.View, 1, 0, false).
 			AddItem(cv.controls, 1, 0, false).
 			AddItem(cv.tutView.Shared.Bottom.View, 2, 0, false)
@@ -128,6 +138,7 @@ type ComposeControls uint
 const (
 	ComposeNormal ComposeControls = iota
 	ComposeMedia
+	ComposeCrossPost
 )
 
 func urlsInText(txt string) (count, length int) {
@@ -141,11 +152,17 @@ func urlsInText(txt string) (count, length int) {
 }
 
 func (cv *ComposeView) msgLength() int {
+	return cv.msgLengthFor(cv.tutView.tut.Client)
+}
+
+// msgLengthFor returns how many characters that are left of the limit of the
+// instance of ac.
+func (cv *ComposeView) msgLengthFor(ac *api.AccountClient) int {
 	m := cv.msg
 	charCount := uniseg.GraphemeClusterCount(m.Text)
 	spoilerCount := uniseg.GraphemeClusterCount(m.CWText)
 	totalCount := charCount
-	urlLength := cv.tutView.tut.Client.GetLengthURL()
+	urlLength := ac.GetLengthURL()
 
 	urls, length := urlsInText(m.Text)
 	if urls > 0 {
@@ -156,7 +173,7 @@ func (cv *ComposeView) msgLength() int {
 	if m.Sensitive {
 		totalCount += spoilerCount
 	}
-	charsLeft := cv.tutView.tut.Client.GetCharLimit() - totalCount
+	charsLeft := ac.GetCharLimit() - totalCount
 	return charsLeft
 }
 
@@ -172,14 +189,24 @@ func (cv *ComposeView) SetControls(ctrl ComposeControls) {
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.ComposeMediaFocus, true))
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.ComposePoll, true))
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.ComposeLanguage, true))
+		if cv.msg.Edit == nil {
+			items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.ComposeSchedule, true))
+		}
 		if cv.msg.Reply != nil {
 			items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.ComposeIncludeQuote, true))
 		}
+		if cv.crossPost.Enabled() {
+			items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.ComposeAccounts, true))
+		}
 	case ComposeMedia:
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.MediaAdd, true))
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.MediaDelete, true))
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.MediaEditDesc, true))
 		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.GlobalBack, true))
+	case ComposeCrossPost:
+		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.CrossPostToggle, true))
+		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.CrossPostVisibility, true))
+		items = append(items, NewControl(cv.tutView.tut.Config, cv.tutView.tut.Config.Input.GlobalBack, true))
 	}
 	cv.controls.Clear()
 	for i, item := range items {
@@ -279,10 +306,15 @@ func (cv *ComposeView) SetStatus(reply *mastodon.Status, edit *mastodon.Status)
 	cv.lang.SetOptions(langStrs, cv.langSelected)
 	cv.lang.SetCurrentOption(index)
 
+	cv.schedule.SetLabel("Schedule: ")
+	cv.schedule.SetText(cv.scheduleText())
+
+	cv.draftBase = cv.msg.Text
 	if cv.tutView.tut.Config.General.UseInternalEditor {
 		cv.textAreaMain.SetText(cv.msg.Text, true)
 		cv.textAreaCW.SetText(cv.msg.CWText, true)
 	}
+	cv.crossPost.Reset(cv.msg.Reply == nil && cv.msg.Edit == nil)
 	cv.UpdateContent()
 	cv.SetControls(ComposeNormal)
 	return nil
@@ -345,7 +377,11 @@ func (cv *ComposeView) ToggleCW() {
 }
 
 func (cv *ComposeView) UpdateContent() {
-	cv.info.SetText(fmt.Sprintf("Chars left: %d\nCW: %t\nHas poll: %t\n", cv.msgLength(), cv.msg.Sensitive, cv.tutView.PollView.HasPoll()))
+	scheduled := "no"
+	if cv.msg.ScheduledAt != nil {
+		scheduled = cv.msg.ScheduledAt.Format("2006-01-02 15:04")
+	}
+	cv.info.SetText(fmt.Sprintf("Chars left: %d\nCW: %t\nHas poll: %t\nScheduled: %s\n", cv.msgLength(), cv.msg.Sensitive, cv.tutView.PollView.HasPoll(), scheduled))
 	normal := config.ColorMark(cv.tutView.tut.Config.Style.Text)
 	subtleColor := config.ColorMark(cv.tutView.tut.Config.Style.Subtle)
 	warningColor := config.ColorMark(cv.tutView.tut.Config.Style.WarningText)
@@ -381,6 +417,160 @@ func (cv *ComposeView) UpdateContent() {
 	}
 
 	cv.content.SetText(output)
+	cv.crossPost.Draw()
+	cv.saveDraft()
+}
+
+func (cv *ComposeView) hasDraftContent() bool {
+	return strings.TrimSpace(cv.msg.Text) != strings.TrimSpace(cv.draftBase) ||
+		strings.TrimSpace(cv.msg.CWText) != "" ||
+		cv.HasMedia() || cv.tutView.PollView.HasPoll()
+}
+
+func (cv *ComposeView) saveDraft() {
+	// Edits of posted or scheduled toots are stored on the server, so they
+	// don't get a draft.
+	if cv.msg.Edit != nil || cv.msg.Scheduled != nil {
+		return
+	}
+	if !cv.hasDraftContent() {
+		if cv.msg.Draft != nil {
+			cv.removeDraft()
+		}
+		return
+	}
+	d := cv.msg.Draft
+	if d == nil {
+		d = &util.Draft{}
+	}
+	d.Text = cv.msg.Text
+	d.CWText = cv.msg.CWText
+	d.Sensitive = cv.msg.Sensitive
+	d.Visibility = cv.msg.Visibility
+	d.Language = cv.msg.Language
+	d.InReplyToID = cv.msg.ReplyID
+	if cv.msg.Reply != nil {
+		d.InReplyToID = cv.msg.Reply.ID
+	}
+	d.Media = nil
+	for _, f := range cv.media.Files {
+		if f.Remote {
+			continue
+		}
+		d.Media = append(d.Media, util.DraftMedia{Path: f.Path, Description: f.Description})
+	}
+	d.Poll = nil
+	if cv.tutView.PollView.HasPoll() {
+		poll := cv.tutView.PollView.GetPoll()
+		d.Poll = &util.DraftPoll{
+			Options:   poll.Options,
+			ExpiresIn: poll.ExpiresInSeconds,
+			Multiple:  poll.Multiple,
+		}
+	}
+	err := cv.tutView.tut.Client.SaveDraft(d)
+	if err != nil {
+		cv.tutView.ShowError(
+			fmt.Sprintf("Couldn't save draft. Error: %v\n", err),
+		)
+		return
+	}
+	cv.msg.Draft = d
+}
+
+func (cv *ComposeView) removeDraft() {
+	d := cv.msg.Draft
+	if d == nil {
+		return
+	}
+	err := cv.tutView.tut.Client.DeleteDraft(d)
+	if err != nil {
+		cv.tutView.ShowError(
+			fmt.Sprintf("Couldn't delete draft. Error: %v\n", err),
+		)
+		return
+	}
+	cv.msg.Draft = nil
+	cv.tutView.RemoveDraft(d)
+}
+
+// UnsentDraft returns the latest draft for the toot that is being composed,
+// i.e. a draft replying to the same toot or a draft that isn't a reply.
+func (cv *ComposeView) UnsentDraft() *util.Draft {
+	if cv.msg.Edit != nil || cv.msg.Scheduled != nil {
+		return nil
+	}
+	drafts, err := cv.tutView.tut.Client.LoadDrafts()
+	if err != nil {
+		return nil
+	}
+	id := cv.msg.ReplyID
+	if cv.msg.Reply != nil {
+		id = cv.msg.Reply.ID
+	}
+	for _, d := range drafts {
+		if d.InReplyToID == id {
+			return d
+		}
+	}
+	return nil
+}
+
+func (cv *ComposeView) SetDraft(d *util.Draft) error {
+	var reply *mastodon.Status
+	var replyErr error
+	if d.InReplyToID != "" {
+		reply, replyErr = cv.tutView.tut.Client.GetStatus(d.InReplyToID)
+	}
+	err := cv.SetStatus(reply, nil)
+	if err != nil {
+		return err
+	}
+	// The draft is saved again when you edit it, so the reply has to be kept
+	// even if the toot can't be loaded right now.
+	if replyErr != nil {
+		cv.msg.ReplyID = d.InReplyToID
+		cv.tutView.ShowError(
+			fmt.Sprintf("Couldn't load the toot it replies to, it's still a reply. Error: %v\n", replyErr),
+		)
+	}
+	cv.msg.Draft = d
+	cv.msg.Text = d.Text
+	cv.msg.CWText = d.CWText
+	cv.msg.Sensitive = d.Sensitive
+	if d.Visibility != "" {
+		cv.msg.Visibility = d.Visibility
+		cv.visibility.SetCurrentOption(visibilities[d.Visibility])
+	}
+	if d.Language != "" {
+		cv.msg.Language = d.Language
+		for i, l := range util.Languages {
+			if l.Code == d.Language {
+				cv.lang.SetCurrentOption(i)
+				break
+			}
+		}
+	}
+	for _, m := range d.Media {
+		cv.media.AddFile(m.Path)
+		cv.media.Files[len(cv.media.Files)-1].Description = m.Description
+	}
+	if d.Poll != nil && len(d.Media) == 0 {
+		poll := &mastodon.Poll{
+			Multiple:  d.Poll.Multiple,
+			ExpiresAt: time.Now().Add(time.Duration(d.Poll.ExpiresIn) * time.Second),
+		}
+		for _, o := range d.Poll.Options {
+			poll.Options = append(poll.Options, mastodon.PollOption{Title: o})
+		}
+		cv.tutView.PollView.AddPoll(poll)
+	}
+	if cv.tutView.tut.Config.General.UseInternalEditor {
+		cv.textAreaMain.SetText(cv.msg.Text, true)
+		cv.textAreaCW.SetText(cv.msg.CWText, true)
+	}
+	cv.UpdateContent()
+	return nil
 }
 
 func (cv *ComposeView) IncludeQuote() {
@@ -469,6 +659,7 @@ func (cv *ComposeView) exitVisibility() {
 
 func (cv *ComposeView) visibilitySelected(s string, index int) {
 	_, cv.msg.Visibility = cv.visibility.GetCurrentOption()
+	cv.crossPost.Draw()
 	cv.exitVisibility()
 }
 
@@ -514,13 +705,144 @@ func (cv *ComposeView) FocusLang() {
 	cv.tutView.tut.App.QueueEvent(ev)
 }
 
-func (cv *ComposeView) Post() {
+func (cv *ComposeView) scheduleText() string {
+	if cv.msg.ScheduledAt == nil {
+		return ""
+	}
+	return cv.msg.ScheduledAt.Format("2006-01-02 15:04")
+}
+
+func (cv *ComposeView) scheduleInput(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() == tcell.KeyEnter {
+		t, err := util.ParseSchedule(cv.schedule.GetText(), time.Now())
+		if err != nil {
+			cv.tutView.ShowError(
+				fmt.Sprintf("Couldn't schedule toot. Error: %v\n", err),
+			)
+			return nil
+		}
+		cv.msg.ScheduledAt = t
+		cv.exitSchedule()
+		return nil
+	}
+	if cv.tutView.tut.Config.Input.GlobalBack.Match(event.Key(), rune(-1)) {
+		cv.exitSchedule()
+		return nil
+	}
+	return event
+}
+
+func (cv *ComposeView) exitSchedule() {
+	cv.schedule.SetText(cv.scheduleText())
+	cv.tutView.Shared.Bottom.Cmd.ClearInput()
+	cv.tutView.tut.App.SetInputCapture(cv.tutView.Input)
+	cv.tutView.tut.App.SetFocus(cv.content)
+	cv.UpdateContent()
+}
+
+func (cv *ComposeView) FocusSchedule() {
+	if cv.msg.Edit != nil {
+		cv.tutView.ShowError("Can't schedule an edit of a toot")
+		return
+	}
+	cv.tutView.tut.App.SetInputCapture(cv.scheduleInput)
+	cv.tutView.tut.App.SetFocus(cv.schedule)
+}
+
+func (cv *ComposeView) SetScheduled(s *api.ScheduledStatus) error {
+	var reply *mastodon.Status
+	var replyErr error
+	if s.Params.InReplyToID != "" {
+		reply, replyErr = cv.tutView.tut.Client.GetStatus(s.Params.InReplyToID)
+	}
+	err := cv.SetStatus(reply, nil)
+	if err != nil {
+		return err
+	}
+	if replyErr != nil {
+		cv.msg.ReplyID = s.Params.InReplyToID
+		cv.tutView.ShowError(
+			fmt.Sprintf("Couldn't load the toot it replies to, it's still a reply. Error: %v\n", replyErr),
+		)
+	}
+	cv.msg.Scheduled = s
+	cv.crossPost.Reset(false)
+	cv.msg.Text = s.Params.Text
+	cv.msg.CWText = s.Params.SpoilerText
+	cv.msg.Sensitive = s.Params.IsSensitive()
+	if s.Params.Visibility != "" {
+		cv.msg.Visibility = s.Params.Visibility
+		cv.visibility.SetCurrentOption(visibilities[s.Params.Visibility])
+	}
+	if s.Params.Language != "" {
+		cv.msg.Language = s.Params.Language
+		for i, l := range util.Languages {
+			if l.Code == s.Params.Language {
+				cv.lang.SetCurrentOption(i)
+				break
+			}
+		}
+	}
+	at := s.ScheduledAt.Local()
+	cv.msg.ScheduledAt = &at
+	cv.schedule.SetText(cv.scheduleText())
+	if len(s.MediaAttachments) > 0 {
+		cv.media.AddFromEdit(&mastodon.Status{MediaAttachments: s.MediaAttachments})
+	}
+	if s.Params.Poll != nil {
+		poll := &mastodon.Poll{
+			Multiple:  s.Params.Poll.IsMultiple(),
+			ExpiresAt: time.Now().Add(time.Duration(s.Params.Poll.ExpiresInSeconds()) * time.Second),
+		}
+		for _, o := range s.Params.Poll.Options {
+			poll.Options = append(poll.Options, mastodon.PollOption{Title: o})
+		}
+		cv.tutView.PollView.AddPoll(poll)
+	}
+	if cv.tutView.tut.Config.General.UseInternalEditor {
+		cv.textAreaMain.SetText(cv.msg.Text, true)
+		cv.textAreaCW.SetText(cv.msg.CWText, true)
+	}
+	cv.UpdateContent()
+	return nil
+}
+
+// onlyTimeChanged is true if send is the same toot as s, so s can be moved to
+// the new time instead of being scheduled again.
+func onlyTimeChanged(s *api.ScheduledStatus, send *mastodon.Toot) bool {
+	p := s.Params
+	if p.Text != send.Status || p.InReplyToID != send.InReplyToID ||
+		p.Visibility != send.Visibility || p.Language != send.Language ||
+		p.IsSensitive() != send.Sensitive || p.SpoilerText != send.SpoilerText {
+		return false
+	}
+	if !slices.Equal(p.MediaIDs, send.MediaIDs) {
+		return false
+	}
+	if (p.Poll == nil) != (send.Poll == nil) {
+		return false
+	}
+	if p.Poll == nil {
+		return true
+	}
+	// The expiration is rounded when the poll is loaded.
+	d := p.Poll.ExpiresInSeconds() - send.Poll.ExpiresInSeconds
+	return slices.Equal(p.Poll.Options, send.Poll.Options) &&
+		p.Poll.IsMultiple() == send.Poll.Multiple && d >= -5 && d <= 5
+}
+
+// newToot returns the toot that is composed, with the media uploaded by ac.
+// uploaded has the media already uploaded to each server, it's reused if ac
+// can use it. It can be nil.
+func (cv *ComposeView) newToot(ac *api.AccountClient, uploaded map[string][]mastodon.ID) (*mastodon.Toot, error) {
 	toot := cv.msg
-	send := mastodon.Toot{
+	send := &mastodon.Toot{
 		Status: strings.TrimSpace(toot.Text),
 	}
 	if toot.Reply != nil {
 		send.InReplyToID = toot.Reply.ID
+	} else if toot.ReplyID != "" {
+		send.InReplyToID = toot.ReplyID
 	}
 	if toot.Edit != nil && toot.Edit.InReplyToID != nil {
 		send.InReplyToID = mastodon.ID(toot.Edit.InReplyToID.(string))
@@ -530,49 +852,112 @@ func (cv *ComposeView) Post() {
 		send.SpoilerText = toot.CWText
 	}
 
-	if cv.HasMedia() {
-		attachments := cv.media.Files
-		for _, ap := range attachments {
-			if ap.Remote {
-				send.MediaIDs = append(send.MediaIDs, ap.ID)
-				continue
-			}
-			f, err := os.Open(ap.Path)
-			if err != nil {
-				cv.tutView.ShowError(
-					fmt.Sprintf("Couldn't upload media. Error: %v\n", err),
-				)
-				f.Close()
-				return
-			}
-			media := &mastodon.Media{
-				File: f,
-			}
-			if ap.Description != "" {
-				media.Description = ap.Description
-			}
-			a, err := cv.tutView.tut.Client.Client.UploadMediaFromMedia(context.Background(), media)
-			if err != nil {
-				cv.tutView.ShowError(
-					fmt.Sprintf("Couldn't upload media. Error: %v\n", err),
-				)
-				f.Close()
-				return
-			}
-			f.Close()
-			send.MediaIDs = append(send.MediaIDs, a.ID)
+	// The media is uploaded once for each server. Mastodon silently drops
+	// media that the account didn't upload itself, so it's only reused when
+	// ac can see it.
+	server := ac.Client.Config.Server
+	var ids []mastodon.ID
+	for _, ap := range cv.media.Files {
+		if ap.Remote {
+			send.MediaIDs = append(send.MediaIDs, ap.ID)
+			continue
+		}
+		if n := len(ids); n < len(uploaded[server]) && ac.CanUseMedia(uploaded[server][n]) {
+			ids = append(ids, uploaded[server][n])
+			send.MediaIDs = append(send.MediaIDs, uploaded[server][n])
+			continue
+		}
+		f, err := os.Open(ap.Path)
+		if err != nil {
+			return nil, err
+		}
+		media := &mastodon.Media{
+			File: f,
 		}
+		if ap.Description != "" {
+			media.Description = ap.Description
+		}
+		a, err := ac.Client.UploadMediaFromMedia(context.Background(), media)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, a.ID)
+		send.MediaIDs = append(send.MediaIDs, a.ID)
+	}
+	if uploaded != nil {
+		uploaded[server] = ids
 	}
 	if cv.tutView.PollView.HasPoll() && !cv.HasMedia() {
 		send.Poll = cv.tutView.PollView.GetPoll()
 	}
-	send.Visibility = cv.msg.Visibility
-	send.Language = cv.msg.Language
+	send.Visibility = toot.Visibility
+	send.Language = toot.Language
+	if toot.ScheduledAt != nil && toot.Edit == nil {
+		send.ScheduledAt = toot.ScheduledAt
+	}
+	return send, nil
+}
+
+func (cv *ComposeView) Post() {
+	if cv.crossPost.IsCrossPost() {
+		cv.postToAccounts()
+		return
+	}
+	toot := cv.msg
+	send, err := cv.newToot(cv.tutView.tut.Client, nil)
+	if err != nil {
+		cv.tutView.ShowError(
+			fmt.Sprintf("Couldn't upload media. Error: %v\n", err),
+		)
+		return
+	}
 
-	var err error
 	var newPost *mastodon.Status
+	if send.ScheduledAt != nil {
+		var scheduled *api.ScheduledStatus
+		old := toot.Scheduled
+		if old != nil && onlyTimeChanged(old, send) {
+			scheduled, err = cv.tutView.tut.Client.RescheduleStatus(old, *send.ScheduledAt)
+		} else {
+			// The old one is removed first, so you don't end up with both
+			// if it can't be removed.
+			if old != nil {
+				err = cv.tutView.tut.Client.CancelScheduledStatus(old)
+				if err != nil {
+					cv.tutView.ShowError(
+						fmt.Sprintf("Couldn't remove the old scheduled toot, so it wasn't changed. Error: %v\n", err),
+					)
+					return
+				}
+				toot.Scheduled = nil
+			}
+			scheduled, err = cv.tutView.tut.Client.ScheduleStatus(send)
+		}
+		if err != nil {
+			if old != nil && toot.Scheduled == nil {
+				cv.tutView.RemoveScheduled(old)
+				cv.tutView.ShowError(
+					fmt.Sprintf("The old scheduled toot was removed, but the new one couldn't be scheduled. Try again. Error: %v\n", err),
+				)
+				return
+			}
+			cv.tutView.ShowError(
+				fmt.Sprintf("Couldn't schedule toot. Error: %v\n", err),
+			)
+			return
+		}
+		if old != nil {
+			*old = *scheduled
+			cv.tutView.GetCurrentFeed().Data.Updated(feed.DesktopNotificationHolder{Type: feed.DesktopNotificationNone})
+			cv.tutView.RedrawContent()
+		}
+		cv.removeDraft()
+		cv.tutView.SetPage(MainFocus)
+		return
+	}
 	if toot.Edit != nil {
-		newPost, err = cv.tutView.tut.Client.Client.UpdateStatus(context.Background(), &send, toot.Edit.ID)
+		newPost, err = cv.tutView.tut.Client.Client.UpdateStatus(context.Background(), send, toot.Edit.ID)
 		if err == nil {
 			item, itemErr := cv.tutView.GetCurrentItem()
 			if itemErr != nil {
@@ -586,7 +971,7 @@ func (cv *ComposeView) Post() {
 			cv.tutView.RedrawContent()
 		}
 	} else {
-		_, err = cv.tutView.tut.Client.Client.PostStatus(context.Background(), &send)
+		_, err = cv.tutView.tut.Client.Client.PostStatus(context.Background(), send)
 	}
 	if err != nil {
 		cv.tutView.ShowError(
@@ -594,6 +979,17 @@ func (cv *ComposeView) Post() {
 		)
 		return
 	}
+	if toot.Scheduled != nil {
+		err = cv.tutView.tut.Client.CancelScheduledStatus(toot.Scheduled)
+		if err != nil {
+			cv.tutView.ShowError(
+				fmt.Sprintf("Toot was posted, but the scheduled one couldn't be removed. Error: %v\n", err),
+			)
+			return
+		}
+		cv.tutView.RemoveScheduled(toot.Scheduled)
+	}
+	cv.removeDraft()
 	cv.tutView.SetPage(MainFocus)
 }
 
@@ -0,0 +1,223 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/rivo/tview"
+)
+
+// crossPostTarget is an account a new toot can be posted to. The toot gets
+// its own visibility on each account.
+type crossPostTarget struct {
+	tutView    *TutView
+	selected   bool
+	visibility string
+}
+
+func (t *crossPostTarget) name() string {
+	return t.tutView.accountName()
+}
+
+type CrossPostList struct {
+	tutView *TutView
+	View    *tview.Flex
+	heading *tview.TextView
+	list    *tview.List
+	targets []*crossPostTarget
+}
+
+func NewCrossPostList(tv *TutView) *CrossPostList {
+	cp := &CrossPostList{
+		tutView: tv,
+		heading: NewTextView(tv.tut.Config),
+		list:    NewList(tv.tut.Config),
+	}
+	cp.heading.SetBorderPadding(1, 0, 0, 0)
+	cp.View = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(cp.heading, 2, 0, false).
+		AddItem(cp.list, 0, 1, false)
+	return cp
+}
+
+// Reset lists the accounts you are logged in to. The toot is only posted to
+// the current account until you select more. A reply or an edit can only be
+// posted by the current account, so then there aren't any other accounts.
+func (cp *CrossPostList) Reset(enabled bool) {
+	cp.targets = nil
+	cp.list.Clear()
+	if enabled {
+		for _, t := range TutViews.Views {
+			if t.tut.Client == nil || t.tut.Client.Me == nil {
+				continue
+			}
+			cp.targets = append(cp.targets, &crossPostTarget{
+				tutView:  t,
+				selected: t == cp.tutView,
+			})
+		}
+	}
+	if !cp.Enabled() {
+		cp.targets = nil
+	}
+	cp.Draw()
+}
+
+// Enabled is true if there are other accounts to post the toot to.
+func (cp *CrossPostList) Enabled() bool {
+	return len(cp.targets) > 1
+}
+
+// IsCrossPost is true if the toot isn't only posted by the current account.
+func (cp *CrossPostList) IsCrossPost() bool {
+	if !cp.Enabled() {
+		return false
+	}
+	selected := cp.Selected()
+	return len(selected) != 1 || selected[0].tutView != cp.tutView
+}
+
+func (cp *CrossPostList) Selected() []*crossPostTarget {
+	var selected []*crossPostTarget
+	for _, t := range cp.targets {
+		if t.selected {
+			selected = append(selected, t)
+		}
+	}
+	return selected
+}
+
+// Visibility of the toot on the account of t. The visibility of the current
+// account is the one in the compose view.
+func (cp *CrossPostList) Visibility(t *crossPostTarget) string {
+	if t.tutView == cp.tutView || t.visibility == "" {
+		return cp.tutView.ComposeView.msg.Visibility
+	}
+	return t.visibility
+}
+
+func (cp *CrossPostList) Draw() {
+	index := cp.list.GetCurrentItem()
+	cp.list.Clear()
+	if !cp.Enabled() {
+		cp.heading.SetText("")
+		return
+	}
+	cp.heading.SetText(fmt.Sprintf("Post to: %d of %d accounts", len(cp.Selected()), len(cp.targets)))
+	cv := cp.tutView.ComposeView
+	normal := config.ColorMark(cp.tutView.tut.Config.Style.Text)
+	warning := config.ColorMark(cp.tutView.tut.Config.Style.WarningText)
+	for _, t := range cp.targets {
+		check := "[ ]"
+		if t.selected {
+			check = "[x]"
+		}
+		left := cv.msgLengthFor(t.tutView.tut.Client)
+		chars := fmt.Sprintf("%d", left)
+		if left < 0 {
+			chars = warning + chars + normal
+		}
+		cp.list.AddItem(fmt.Sprintf("%s %s %s %s", tview.Escape(check), tview.Escape(t.name()), cp.Visibility(t), chars), "", 0, nil)
+	}
+	if index >= 0 && index < cp.list.GetItemCount() {
+		cp.list.SetCurrentItem(index)
+	}
+}
+
+func (cp *CrossPostList) current() *crossPostTarget {
+	index := cp.list.GetCurrentItem()
+	if index < 0 || index >= len(cp.targets) {
+		return nil
+	}
+	return cp.targets[index]
+}
+
+func (cp *CrossPostList) Prev() {
+	index := cp.list.GetCurrentItem()
+	if index-1 >= 0 {
+		cp.list.SetCurrentItem(index - 1)
+	}
+}
+
+func (cp *CrossPostList) Next() {
+	index := cp.list.GetCurrentItem()
+	if index+1 < cp.list.GetItemCount() {
+		cp.list.SetCurrentItem(index + 1)
+	}
+}
+
+func (cp *CrossPostList) Toggle() {
+	t := cp.current()
+	if t == nil {
+		return
+	}
+	t.selected = !t.selected
+	cp.Draw()
+}
+
+// NextVisibility changes the visibility on the selected account. For the
+// current account it's the same as changing it in the compose view.
+func (cp *CrossPostList) NextVisibility() {
+	t := cp.current()
+	if t == nil {
+		return
+	}
+	next := visibilitiesStr[(visibilities[cp.Visibility(t)]+1)%len(visibilitiesStr)]
+	if t.tutView == cp.tutView {
+		cv := cp.tutView.ComposeView
+		cv.msg.Visibility = next
+		cv.visibility.SetCurrentOption(visibilities[next])
+	} else {
+		t.visibility = next
+	}
+	cp.Draw()
+}
+
+// postToAccounts posts the toot to all selected accounts. The accounts that
+// got the toot are unselected, so you can try again with the ones that failed.
+func (cv *ComposeView) postToAccounts() {
+	targets := cv.crossPost.Selected()
+	if len(targets) == 0 {
+		cv.tutView.ShowError("Select an account to post the toot to")
+		return
+	}
+	var posted, failed []string
+	uploaded := make(map[string][]mastodon.ID)
+	for _, t := range targets {
+		ac := t.tutView.tut.Client
+		if left := cv.msgLengthFor(ac); left < 0 {
+			failed = append(failed, fmt.Sprintf("%s (%d characters too long)", t.name(), -left))
+			continue
+		}
+		send, err := cv.newToot(ac, uploaded)
+		if err == nil {
+			send.Visibility = cv.crossPost.Visibility(t)
+			if send.ScheduledAt != nil {
+				_, err = ac.ScheduleStatus(send)
+			} else {
+				_, err = ac.Client.PostStatus(context.Background(), send)
+			}
+		}
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", t.name(), err))
+			continue
+		}
+		t.selected = false
+		posted = append(posted, t.name())
+	}
+	cv.crossPost.Draw()
+	if len(failed) > 0 {
+		msg := fmt.Sprintf("Couldn't post to %s", strings.Join(failed, ", "))
+		if len(posted) > 0 {
+			msg = fmt.Sprintf("Posted to %s. %s", strings.Join(posted, ", "), msg)
+		}
+		cv.tutView.ShowError(msg)
+		return
+	}
+	cv.removeDraft()
+	cv.tutView.SetPage(MainFocus)
+	cv.tutView.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Posted to %s", strings.Join(posted, ", ")))
+}
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strconv"
+	"time"
 
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
@@ -12,12 +13,14 @@ import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/gen2brain/beeep"
 	"github.com/rivo/tview"
+	"golang.org/x/exp/slices"
 )
 
 type FeedList struct {
 	Text        *tview.List
 	Symbol      *tview.List
 	stickyCount int
+	separator   int
 }
 
 func (fl *FeedList) InFocus(style config.Style) {
@@ -50,6 +53,8 @@ type Feed struct {
 	List     *FeedList
 	Content  *FeedContent
 	Timeline *config.Timeline
+
+	markerRestored bool
 }
 
 func (f *Feed) ListInFocus() {
@@ -82,11 +87,30 @@ func (f *Feed) DrawContent() {
 		if id != item.ID() {
 			continue
 		}
-		DrawItem(f.tutView, item, f.Content.Main, f.Content.Controls, f.Data.Type())
+		DrawItem(f.tutView, item, f.Content.Main, f.Content.Controls, f.Data.FilterType())
 		f.tutView.ShouldSync()
 	}
 }
 
+// SelectThreadStatus selects the status with id in a thread feed.
+func (f *Feed) SelectThreadStatus(id mastodon.ID) {
+	item, ok := f.Data.ThreadItem(id)
+	if !ok {
+		return
+	}
+	f.List.SetByID(item.ID())
+	f.DrawContent()
+}
+
+// badge returns the account that received item if the feed is a unified
+// timeline.
+func (f *Feed) badge(item api.Item) string {
+	if f.Data.Type() != config.Unified {
+		return ""
+	}
+	return clientName(f.Data.ItemClient(item))
+}
+
 func (f *Feed) update() {
 	for nft := range f.Data.Update {
 		switch nft.Type {
@@ -119,30 +143,75 @@ func (f *Feed) update() {
 				beeep.Notify("New post", "", "")
 			}
 		}
+		if err := f.Data.LoadError(); err != nil {
+			f.tutView.tut.App.QueueUpdateDraw(func() {
+				f.tutView.ShowError(fmt.Sprintf("Couldn't load the feed. Error: %v\n", err))
+			})
+		}
 		f.tutView.tut.App.QueueUpdateDraw(func() {
 			lLen := f.List.GetItemCount()
 			curr := f.List.GetCurrentID()
 			f.List.Clear()
+			lastRead, showLastRead := f.Data.LastRead()
 			for _, item := range f.Data.List() {
-				main, symbol := DrawListItem(f.tutView.tut.Config, item)
+				if showLastRead && item == lastRead {
+					f.List.AddSeparator(config.SublteText(f.tutView.tut.Config, "──── last read ────"))
+				}
+				main, symbol := DrawListItem(f.tutView.tut.Config, item, f.badge(item))
 				f.List.AddItem(main, symbol, item.ID())
 			}
-			if f.tutView.tut.Config.General.StickToTop {
+			// The first time the feed gets items it opens where you stopped
+			// reading.
+			restore := !f.markerRestored && f.List.GetItemCount() > 0
+			if restore {
+				f.markerRestored = true
+			}
+			// A thread keeps the selected toot when replies are collapsed
+			// or new replies arrive.
+			if restore && lastRead != nil && !f.tutView.tut.Config.General.StickToTop {
+				f.List.SetByID(lastRead.ID())
+				f.DrawContent()
+			} else if f.tutView.tut.Config.General.StickToTop && f.Data.Type() != config.Thread {
 				f.List.SetCurrentItem(f.List.stickyCount)
 				f.DrawContent()
+				if f.tutView.GetCurrentFeed() == f {
+					f.Data.MarkSeen(f.List.GetCurrentIndex())
+				}
 			} else {
 				f.List.SetByID(curr)
 			}
-			if lLen == 0 {
+			if lLen == 0 || f.List.GetCurrentID() != curr ||
+				slices.Contains(f.Data.EditedItems(), curr) {
 				f.DrawContent()
 			}
+			if f.tutView.PageFocus == MainFocus && f.tutView.GetCurrentFeed() == f {
+				f.tutView.Shared.Top.SetText(f.tutView.Timeline.GetTitle())
+			}
+			TutViews.UpdateUnseen()
 		})
 	}
 }
 
+// newFeed loads the first items of f and shows it in a new Feed.
+func newFeed(tv *TutView, f *feed.Feed, tl *config.Timeline) *Feed {
+	f.LoadNewer()
+	fd := &Feed{
+		tutView:  tv,
+		Data:     f,
+		List:     NewFeedList(tv.tut, f.StickyCount()),
+		Content:  NewFeedContent(tv.tut),
+		Timeline: tl,
+	}
+	go fd.update()
+
+	return fd
+}
+
 func NewHomeFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewTimelineHome(tv.tut.Client, tv.tut.Config, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
+	f.LoadToMarker()
 	fd := &Feed{
 		tutView:  tv,
 		Data:     f,
@@ -157,6 +226,7 @@ func NewHomeFeed(tv *TutView, tl *config.Timeline) *Feed {
 
 func NewHomeSpecialFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewTimelineHomeSpecial(tv.tut.Client, tv.tut.Config, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -172,6 +242,7 @@ func NewHomeSpecialFeed(tv *TutView, tl *config.Timeline) *Feed {
 
 func NewFederatedFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewTimelineFederated(tv.tut.Client, tv.tut.Config, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -187,6 +258,7 @@ func NewFederatedFeed(tv *TutView, tl *config.Timeline) *Feed {
 
 func NewLocalFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewTimelineLocal(tv.tut.Client, tv.tut.Config, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -202,7 +274,9 @@ func NewLocalFeed(tv *TutView, tl *config.Timeline) *Feed {
 
 func NewNotificationFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewNotifications(tv.tut.Client, tv.tut.Config, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
+	f.LoadToMarker()
 	fd := &Feed{
 		tutView:  tv,
 		Data:     f,
@@ -217,6 +291,7 @@ func NewNotificationFeed(tv *TutView, tl *config.Timeline) *Feed {
 
 func NewNotificatioMentionsFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewNotificationsMentions(tv.tut.Client, tv.tut.Config)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -230,6 +305,27 @@ func NewNotificatioMentionsFeed(tv *TutView, tl *config.Timeline) *Feed {
 	return fd
 }
 
+// NewUnifiedFeed merges the feeds of all accounts you are logged in to. The
+// accounts that log in later are added by joinUnified.
+func NewUnifiedFeed(tv *TutView, tl *config.Timeline) *Feed {
+	f := feed.NewUnified(tv.tut.Client, tv.tut.Config, tl.Subaction, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
+	f.AddAccount(tv.tut.Client)
+	for _, t := range tv.otherAccounts() {
+		f.AddAccount(t.tut.Client)
+	}
+	fd := &Feed{
+		tutView:  tv,
+		Data:     f,
+		List:     NewFeedList(tv.tut, f.StickyCount()),
+		Content:  NewFeedContent(tv.tut),
+		Timeline: tl,
+	}
+	go fd.update()
+
+	return fd
+}
+
 func NewThreadFeed(tv *TutView, item api.Item, tl *config.Timeline) *Feed {
 	status := util.StatusOrReblog(item.Raw().(*mastodon.Status))
 	f := feed.NewThread(tv.tut.Client, tv.tut.Config, status)
@@ -242,13 +338,14 @@ func NewThreadFeed(tv *TutView, item api.Item, tl *config.Timeline) *Feed {
 		Timeline: tl,
 	}
 	for i, s := range f.List() {
-		main, symbol := DrawListItem(tv.tut.Config, s)
+		main, symbol := DrawListItem(tv.tut.Config, s, "")
 		fd.List.AddItem(main, symbol, s.ID())
-		if s.Raw().(*mastodon.Status).ID == item.Raw().(*mastodon.Status).ID {
+		if s.Raw().(*mastodon.Status).ID == status.ID {
 			fd.List.SetCurrentItem(i)
 		}
 	}
 	fd.DrawContent()
+	go fd.update()
 
 	return fd
 }
@@ -265,7 +362,7 @@ func NewHistoryFeed(tv *TutView, item api.Item, tl *config.Timeline) *Feed {
 		Timeline: tl,
 	}
 	for _, s := range f.List() {
-		main, symbol := DrawListItem(tv.tut.Config, s)
+		main, symbol := DrawListItem(tv.tut.Config, s, "")
 		fd.List.AddItem(main, symbol, s.ID())
 	}
 	fd.List.SetCurrentItem(0)
@@ -276,6 +373,7 @@ func NewHistoryFeed(tv *TutView, item api.Item, tl *config.Timeline) *Feed {
 
 func NewConversationsFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewConversations(tv.tut.Client, tv.tut.Config)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -319,7 +417,7 @@ func NewUserSearchFeed(tv *TutView, tl *config.Timeline) *Feed {
 		Timeline: tl,
 	}
 	for _, s := range f.List() {
-		main, symbol := DrawListItem(tv.tut.Config, s)
+		main, symbol := DrawListItem(tv.tut.Config, s, "")
 		fd.List.AddItem(main, symbol, s.ID())
 	}
 	fd.DrawContent()
@@ -327,8 +425,13 @@ func NewUserSearchFeed(tv *TutView, tl *config.Timeline) *Feed {
 	return fd
 }
 
+func NewSearchFeed(tv *TutView, tl *config.Timeline, statusesOnly bool) *Feed {
+	return newFeed(tv, feed.NewSearch(tv.tut.Client, tv.tut.Config, tl.Subaction, statusesOnly), tl)
+}
+
 func NewTagFeed(tv *TutView, tl *config.Timeline) *Feed {
 	f := feed.NewTag(tv.tut.Client, tv.tut.Config, tl.Subaction, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -343,37 +446,16 @@ func NewTagFeed(tv *TutView, tl *config.Timeline) *Feed {
 }
 
 func NewTagsFeed(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewTags(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewTags(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewListsFeed(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewListList(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewListList(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewListFeed(tv *TutView, l *mastodon.List, tl *config.Timeline) *Feed {
 	f := feed.NewList(tv.tut.Client, tv.tut.Config, l, tl.HideBoosts, tl.HideReplies)
+	f.SetPollInterval(time.Duration(tl.PollInterval) * time.Second)
 	f.LoadNewer()
 	fd := &Feed{
 		tutView:  tv,
@@ -388,168 +470,83 @@ func NewListFeed(tv *TutView, l *mastodon.List, tl *config.Timeline) *Feed {
 }
 
 func NewUsersInListFeed(tv *TutView, l *mastodon.List, tl *config.Timeline) *Feed {
-	f := feed.NewUsersInList(tv.tut.Client, tv.tut.Config, l)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewUsersInList(tv.tut.Client, tv.tut.Config, l), tl)
 }
 
 func NewUsersAddListFeed(tv *TutView, l *mastodon.List, tl *config.Timeline) *Feed {
-	f := feed.NewUsersAddList(tv.tut.Client, tv.tut.Config, l)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewUsersAddList(tv.tut.Client, tv.tut.Config, l), tl)
 }
 
 func NewFavoritedFeed(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewFavorites(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-
-	go fd.update()
-	return fd
+	return newFeed(tv, feed.NewFavorites(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewBookmarksFeed(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewBookmarks(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewBookmarks(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewFavoritesStatus(tv *TutView, id mastodon.ID, tl *config.Timeline) *Feed {
-	f := feed.NewFavoritesStatus(tv.tut.Client, tv.tut.Config, id)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewFavoritesStatus(tv.tut.Client, tv.tut.Config, id), tl)
 }
 
 func NewBoosts(tv *TutView, id mastodon.ID, tl *config.Timeline) *Feed {
-	f := feed.NewBoosts(tv.tut.Client, tv.tut.Config, id)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewBoosts(tv.tut.Client, tv.tut.Config, id), tl)
 }
 
 func NewFollowers(tv *TutView, id mastodon.ID, tl *config.Timeline) *Feed {
-	f := feed.NewFollowers(tv.tut.Client, tv.tut.Config, id)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewFollowers(tv.tut.Client, tv.tut.Config, id), tl)
 }
 
 func NewFollowing(tv *TutView, id mastodon.ID, tl *config.Timeline) *Feed {
-	f := feed.NewFollowing(tv.tut.Client, tv.tut.Config, id)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewFollowing(tv.tut.Client, tv.tut.Config, id), tl)
 }
 
 func NewBlocking(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewBlocking(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewBlocking(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewMuting(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewMuting(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
-
-	return fd
+	return newFeed(tv, feed.NewMuting(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewFollowRequests(tv *TutView, tl *config.Timeline) *Feed {
-	f := feed.NewFollowRequests(tv.tut.Client, tv.tut.Config)
-	f.LoadNewer()
-	fd := &Feed{
-		tutView:  tv,
-		Data:     f,
-		List:     NewFeedList(tv.tut, f.StickyCount()),
-		Content:  NewFeedContent(tv.tut),
-		Timeline: tl,
-	}
-	go fd.update()
+	return newFeed(tv, feed.NewFollowRequests(tv.tut.Client, tv.tut.Config), tl)
+}
 
-	return fd
+func NewScheduledFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewScheduled(tv.tut.Client, tv.tut.Config), tl)
+}
+
+func NewFiltersFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewFilters(tv.tut.Client, tv.tut.Config), tl)
+}
+
+func NewDomainBlocksFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewDomainBlocks(tv.tut.Client, tv.tut.Config), tl)
+}
+
+func NewTrendingStatusesFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewTrendingStatuses(tv.tut.Client, tv.tut.Config), tl)
+}
+
+func NewTrendingTagsFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewTrendingTags(tv.tut.Client, tv.tut.Config), tl)
+}
+
+func NewTrendingLinksFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewTrendingLinks(tv.tut.Client, tv.tut.Config), tl)
+}
+
+func NewLinkFeed(tv *TutView, link string, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewLink(tv.tut.Client, tv.tut.Config, link), tl)
+}
+
+func NewFiltersStatusFeed(tv *TutView, status *mastodon.Status, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewFiltersStatus(tv.tut.Client, tv.tut.Config, status), tl)
+}
+
+func NewDraftsFeed(tv *TutView, tl *config.Timeline) *Feed {
+	return newFeed(tv, feed.NewDrafts(tv.tut.Client, tv.tut.Config), tl)
 }
 
 func NewFeedList(t *Tut, stickyCount int) *FeedList {
@@ -557,6 +554,7 @@ func NewFeedList(t *Tut, stickyCount int) *FeedList {
 		Text:        NewList(t.Config),
 		Symbol:      NewList(t.Config),
 		stickyCount: stickyCount,
+		separator:   -1,
 	}
 	return fl
 }
@@ -566,54 +564,76 @@ func (fl *FeedList) AddItem(text string, symbols string, id uint) {
 	fl.Symbol.AddItem(symbols, fmt.Sprintf("%d", id), 0, nil)
 }
 
+// AddSeparator adds a line that can't be selected, e.g. to show where you
+// stopped reading. A list only has one.
+func (fl *FeedList) AddSeparator(text string) {
+	fl.separator = fl.Text.GetItemCount()
+	fl.Text.AddItem(text, "", 0, nil)
+	fl.Symbol.AddItem("", "", 0, nil)
+}
+
+// The separator is a row in the lists but not an item in the feed. All
+// methods of FeedList take and return the index of the item, row and
+// ItemIndex translate between them.
+func (fl *FeedList) row(index int) int {
+	if fl.separator >= 0 && index >= fl.separator {
+		return index + 1
+	}
+	return index
+}
+
+// ItemIndex returns the index of the item at row in the list. The separator
+// returns the item below it.
+func (fl *FeedList) ItemIndex(row int) int {
+	if fl.separator >= 0 && row > fl.separator {
+		return row - 1
+	}
+	return row
+}
+
 func (fl *FeedList) Set(index int) (loadOlder bool, loadNewer bool) {
-	ni := index
-	if ni >= fl.Text.GetItemCount() {
-		ni = fl.Text.GetItemCount() - 1
+	if index >= fl.GetItemCount() {
+		index = fl.GetItemCount() - 1
 	}
-	if ni < 0 {
-		ni = 0
+	if index < 0 {
+		index = 0
 	}
-	fl.Text.SetCurrentItem(ni)
-	fl.Symbol.SetCurrentItem(ni)
-	return fl.Text.GetItemCount()-(ni+1) < 5, ni-fl.stickyCount < 4
+	fl.Text.SetCurrentItem(fl.row(index))
+	fl.Symbol.SetCurrentItem(fl.row(index))
+	return fl.GetItemCount()-(index+1) < 5, index-fl.stickyCount < 4
 }
 
 func (fl *FeedList) Next() (loadOlder bool) {
-	ni := fl.Text.GetCurrentItem() + 1
-	if ni >= fl.Text.GetItemCount() {
-		ni = fl.Text.GetItemCount() - 1
-		if ni < 0 {
-			ni = 0
-		}
-	}
-	fl.Text.SetCurrentItem(ni)
-	fl.Symbol.SetCurrentItem(ni)
-	return fl.Text.GetItemCount()-(ni+1) < 5
+	loadOlder, _ = fl.Set(fl.GetCurrentIndex() + 1)
+	return loadOlder
 }
 
 func (fl *FeedList) Prev() (loadNewer bool) {
-	ni := fl.Text.GetCurrentItem() - 1
-	if ni < 0 {
-		ni = 0
-	}
-	fl.Text.SetCurrentItem(ni)
-	fl.Symbol.SetCurrentItem(ni)
-	return ni-fl.stickyCount < 4
+	_, loadNewer = fl.Set(fl.GetCurrentIndex() - 1)
+	return loadNewer
 }
 
 func (fl *FeedList) Clear() {
+	fl.separator = -1
 	fl.Text.Clear()
 	fl.Symbol.Clear()
 }
 
+// GetItemCount returns the number of items, the separator isn't counted.
 func (fl *FeedList) GetItemCount() int {
+	if fl.separator >= 0 {
+		return fl.Text.GetItemCount() - 1
+	}
 	return fl.Text.GetItemCount()
 }
 
 func (fl *FeedList) SetCurrentItem(index int) {
-	fl.Text.SetCurrentItem(index)
-	fl.Symbol.SetCurrentItem(index)
+	fl.Set(index)
+}
+
+// GetCurrentIndex returns the index of the selected item in the feed.
+func (fl *FeedList) GetCurrentIndex() int {
+	return fl.ItemIndex(fl.Text.GetCurrentItem())
 }
 
 func (fl *FeedList) GetCurrentID() uint {
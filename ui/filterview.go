@@ -0,0 +1,504 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/util"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+var filterActions = []string{
+	"warn",
+	"hide",
+}
+
+type filterFocusAt uint
+
+const (
+	filterMainFocus filterFocusAt = iota
+	filterContextFocus
+	filterKeywordFocus
+)
+
+type filterState struct {
+	id        mastodon.ID
+	title     string
+	context   []string
+	action    string
+	expiresAt *time.Time
+	keywords  []api.FilterKeyword
+	removed   []mastodon.ID
+}
+
+type FilterView struct {
+	tutView  *TutView
+	shared   *Shared
+	View     *tview.Flex
+	title    *tview.TextView
+	action   *tview.DropDown
+	expires  *tview.TextView
+	contexts *tview.List
+	keywords *tview.List
+	controls *tview.Flex
+	filter   *filterState
+	focus    filterFocusAt
+}
+
+func NewFilterView(tv *TutView) *FilterView {
+	f := &FilterView{
+		tutView:  tv,
+		shared:   tv.Shared,
+		title:    NewTextView(tv.tut.Config),
+		action:   NewDropDown(tv.tut.Config),
+		expires:  NewTextView(tv.tut.Config),
+		contexts: NewList(tv.tut.Config),
+		keywords: NewList(tv.tut.Config),
+		controls: NewControlView(tv.tut.Config),
+		filter:   &filterState{},
+	}
+	f.View = filterViewUI(f)
+	f.MainFocus()
+
+	return f
+}
+
+func filterViewUI(f *FilterView) *tview.Flex {
+	f.action.SetLabel("Action: ")
+	f.action.SetOptions(filterActions, f.actionSelected)
+
+	r := tview.NewFlex().SetDirection(tview.FlexRow)
+	if f.tutView.tut.Config.General.TerminalTitle < 2 {
+		r.AddItem(f.shared.Top.View, 1, 0, false)
+	}
+	r.AddItem(tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(f.title, 1, 0, false).
+			AddItem(f.action, 1, 0, false).
+			AddItem(f.expires, 2, 0, false).
+			AddItem(f.contexts, 0, 1, false), 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(f.keywords, 0, 1, false), 0, 1, false), 0, 1, false).
+		AddItem(f.controls, 1, 0, false).
+		AddItem(f.shared.Bottom.View, 2, 0, false)
+	return r
+}
+
+// SetFilter loads a filter into the view. A filter without an ID is created
+// when it's saved.
+func (f *FilterView) SetFilter(filter *api.Filter) {
+	fs := &filterState{
+		id:        filter.ID,
+		title:     filter.Title,
+		context:   append([]string{}, filter.Context...),
+		action:    filter.FilterAction,
+		expiresAt: filter.ExpiresAt,
+		keywords:  append([]api.FilterKeyword{}, filter.Keywords...),
+	}
+	if fs.action == "" {
+		fs.action = filterActions[0]
+	}
+	f.filter = fs
+	f.update()
+	f.contexts.SetCurrentItem(0)
+	f.keywords.SetCurrentItem(0)
+	f.MainFocus()
+}
+
+func (f *FilterView) hasContext(c string) bool {
+	for _, fc := range f.filter.context {
+		if fc == c {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FilterView) update() {
+	fs := f.filter
+	f.title.SetText(fmt.Sprintf("Title: %s", tview.Escape(fs.title)))
+	expires := "never"
+	if fs.expiresAt != nil {
+		expires = fs.expiresAt.Local().Format("2006-01-02 15:04")
+	}
+	f.expires.SetText(fmt.Sprintf("Expires: %s", expires))
+
+	ci := f.contexts.GetCurrentItem()
+	f.contexts.Clear()
+	for _, c := range api.FilterContexts {
+		check := "[ ]"
+		if f.hasContext(c) {
+			check = "[x]"
+		}
+		f.contexts.AddItem(tview.Escape(fmt.Sprintf("%s %s", check, c)), "", 0, nil)
+	}
+	f.contexts.SetCurrentItem(ci)
+
+	ki := f.keywords.GetCurrentItem()
+	f.keywords.Clear()
+	for _, k := range fs.keywords {
+		text := tview.Escape(k.Keyword)
+		if k.WholeWord {
+			text += " (whole word)"
+		}
+		f.keywords.AddItem(text, "", 0, nil)
+	}
+	if ki < f.keywords.GetItemCount() {
+		f.keywords.SetCurrentItem(ki)
+	}
+
+	index := 0
+	for i, a := range filterActions {
+		if fs.action == a {
+			index = i
+			break
+		}
+	}
+	f.action.SetCurrentOption(index)
+}
+
+func (f *FilterView) HasContextFocus() bool {
+	return f.focus == filterContextFocus
+}
+
+func (f *FilterView) HasKeywordFocus() bool {
+	return f.focus == filterKeywordFocus
+}
+
+func (f *FilterView) setControls(keys []Control) {
+	f.controls.Clear()
+	for i, item := range keys {
+		if i < len(keys)-1 {
+			f.controls.AddItem(NewControlButton(f.tutView, item), item.Len+1, 0, false)
+		} else {
+			f.controls.AddItem(NewControlButton(f.tutView, item), item.Len, 0, false)
+		}
+	}
+}
+
+func (f *FilterView) setListFocus(l *tview.List, focus bool) {
+	cnf := f.tutView.tut.Config
+	if focus {
+		l.SetSelectedBackgroundColor(cnf.Style.ListSelectedBackground)
+		l.SetSelectedTextColor(cnf.Style.ListSelectedText)
+	} else {
+		l.SetSelectedBackgroundColor(cnf.Style.Background)
+		l.SetSelectedTextColor(cnf.Style.Text)
+	}
+}
+
+func (f *FilterView) MainFocus() {
+	f.focus = filterMainFocus
+	cnf := f.tutView.tut.Config
+	var items []Control
+	items = append(items, NewControl(cnf, cnf.Input.FilterTitle, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterAction, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterExpires, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterContext, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterKeywords, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterSave, true))
+	f.setControls(items)
+	f.setListFocus(f.contexts, false)
+	f.setListFocus(f.keywords, false)
+}
+
+func (f *FilterView) ContextFocus() {
+	f.focus = filterContextFocus
+	cnf := f.tutView.tut.Config
+	var items []Control
+	items = append(items, NewControl(cnf, cnf.Input.FilterContextToggle, true))
+	items = append(items, NewControl(cnf, cnf.Input.GlobalBack, true))
+	f.setControls(items)
+	f.setListFocus(f.contexts, true)
+	f.setListFocus(f.keywords, false)
+}
+
+func (f *FilterView) KeywordFocus() {
+	f.focus = filterKeywordFocus
+	cnf := f.tutView.tut.Config
+	var items []Control
+	items = append(items, NewControl(cnf, cnf.Input.FilterKeywordAdd, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterKeywordEdit, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterKeywordDelete, true))
+	items = append(items, NewControl(cnf, cnf.Input.FilterKeywordWholeWord, true))
+	items = append(items, NewControl(cnf, cnf.Input.GlobalBack, true))
+	f.setControls(items)
+	f.setListFocus(f.contexts, false)
+	f.setListFocus(f.keywords, true)
+}
+
+func (f *FilterView) focusedList() *tview.List {
+	if f.focus == filterContextFocus {
+		return f.contexts
+	}
+	return f.keywords
+}
+
+func (f *FilterView) Prev() {
+	l := f.focusedList()
+	index := l.GetCurrentItem()
+	if index-1 >= 0 {
+		l.SetCurrentItem(index - 1)
+	}
+}
+
+func (f *FilterView) Next() {
+	l := f.focusedList()
+	index := l.GetCurrentItem()
+	if index+1 < l.GetItemCount() {
+		l.SetCurrentItem(index + 1)
+	}
+}
+
+func (f *FilterView) ToggleContext() {
+	index := f.contexts.GetCurrentItem()
+	if index < 0 || index >= len(api.FilterContexts) {
+		return
+	}
+	c := api.FilterContexts[index]
+	if f.hasContext(c) {
+		var context []string
+		for _, fc := range f.filter.context {
+			if fc != c {
+				context = append(context, fc)
+			}
+		}
+		f.filter.context = context
+	} else {
+		f.filter.context = append(f.filter.context, c)
+	}
+	f.update()
+}
+
+func (f *FilterView) EditTitle() {
+	title := f.filter.title
+	if f.tutView.tut.Config.General.UseInternalEditor {
+		f.tutView.EditorView.Init(title, 100, true, func(input string) {
+			f.editTitle(input, nil)
+		})
+	} else {
+		text, err := OpenEditorLengthLimit(f.tutView, title, 100)
+		f.editTitle(text, err)
+	}
+}
+
+func (f *FilterView) editTitle(text string, err error) {
+	if err != nil {
+		f.tutView.ShowError(
+			fmt.Sprintf("Couldn't edit title. Error: %v\n", err),
+		)
+		return
+	}
+	f.filter.title = strings.TrimSpace(text)
+	f.update()
+}
+
+func (f *FilterView) EditExpires() {
+	expires := ""
+	if f.filter.expiresAt != nil {
+		expires = f.filter.expiresAt.Local().Format("2006-01-02 15:04")
+	}
+	if f.tutView.tut.Config.General.UseInternalEditor {
+		f.tutView.EditorView.Init(expires, 30, true, func(input string) {
+			f.editExpires(input, nil)
+		})
+	} else {
+		text, err := OpenEditorLengthLimit(f.tutView, expires, 30)
+		f.editExpires(text, err)
+	}
+}
+
+// editExpires takes the same format as scheduled toots, e.g. +1d or
+// 2006-01-02 15:04. An empty string or never means that it never expires.
+func (f *FilterView) editExpires(text string, err error) {
+	if err != nil {
+		f.tutView.ShowError(
+			fmt.Sprintf("Couldn't edit expiration. Error: %v\n", err),
+		)
+		return
+	}
+	t, err := util.ParseExpiry(text, time.Now())
+	if err != nil {
+		f.tutView.ShowError(
+			fmt.Sprintf("Couldn't edit expiration. Error: %v\n", err),
+		)
+		return
+	}
+	f.filter.expiresAt = t
+	f.update()
+}
+
+func (f *FilterView) AddKeyword() {
+	if f.tutView.tut.Config.General.UseInternalEditor {
+		f.tutView.EditorView.Init("", 100, true, func(input string) {
+			f.addKeyword(input, nil)
+		})
+	} else {
+		text, err := OpenEditorLengthLimit(f.tutView, "", 100)
+		f.addKeyword(text, err)
+	}
+}
+
+func (f *FilterView) addKeyword(text string, err error) {
+	if err != nil {
+		f.tutView.ShowError(
+			fmt.Sprintf("Couldn't add keyword. Error: %v\n", err),
+		)
+		return
+	}
+	text = strings.TrimSpace(text)
+	if len(text) == 0 {
+		f.tutView.ShowError("Keyword can't be empty.")
+		return
+	}
+	f.filter.keywords = append(f.filter.keywords, api.FilterKeyword{
+		Keyword:   text,
+		WholeWord: true,
+	})
+	f.update()
+	f.keywords.SetCurrentItem(f.keywords.GetItemCount() - 1)
+}
+
+func (f *FilterView) currentKeyword() int {
+	if f.keywords.GetItemCount() == 0 {
+		return -1
+	}
+	index := f.keywords.GetCurrentItem()
+	if index < 0 || index >= len(f.filter.keywords) {
+		return -1
+	}
+	return index
+}
+
+func (f *FilterView) EditKeyword() {
+	index := f.currentKeyword()
+	if index == -1 {
+		return
+	}
+	curr := f.filter.keywords[index].Keyword
+	if f.tutView.tut.Config.General.UseInternalEditor {
+		f.tutView.EditorView.Init(curr, 100, true, func(input string) {
+			f.editKeyword(index, input, nil)
+		})
+	} else {
+		text, err := OpenEditorLengthLimit(f.tutView, curr, 100)
+		f.editKeyword(index, text, err)
+	}
+}
+
+func (f *FilterView) editKeyword(index int, text string, err error) {
+	if err != nil {
+		f.tutView.ShowError(
+			fmt.Sprintf("Couldn't edit keyword. Error: %v\n", err),
+		)
+		return
+	}
+	text = strings.TrimSpace(text)
+	if len(text) == 0 {
+		f.tutView.ShowError("Keyword can't be empty.")
+		return
+	}
+	f.filter.keywords[index].Keyword = text
+	f.update()
+}
+
+func (f *FilterView) DeleteKeyword() {
+	index := f.currentKeyword()
+	if index == -1 {
+		return
+	}
+	if id := f.filter.keywords[index].ID; id != "" {
+		f.filter.removed = append(f.filter.removed, id)
+	}
+	f.keywords.RemoveItem(index)
+	f.filter.keywords = append(f.filter.keywords[:index], f.filter.keywords[index+1:]...)
+	f.update()
+}
+
+func (f *FilterView) ToggleWholeWord() {
+	index := f.currentKeyword()
+	if index == -1 {
+		return
+	}
+	f.filter.keywords[index].WholeWord = !f.filter.keywords[index].WholeWord
+	f.update()
+}
+
+func (f *FilterView) actionInput(event *tcell.EventKey) *tcell.EventKey {
+	if f.tutView.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	}
+	if f.tutView.tut.Config.Input.GlobalUp.Match(event.Key(), event.Rune()) {
+		return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+	}
+	if f.tutView.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) ||
+		f.tutView.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) {
+		f.exitAction()
+		return nil
+	}
+	return event
+}
+
+func (f *FilterView) exitAction() {
+	f.tutView.tut.App.SetInputCapture(f.tutView.Input)
+	f.tutView.tut.App.SetFocus(f.tutView.View)
+}
+
+func (f *FilterView) actionSelected(s string, index int) {
+	_, f.filter.action = f.action.GetCurrentOption()
+	f.exitAction()
+}
+
+func (f *FilterView) FocusAction() {
+	f.tutView.tut.App.SetInputCapture(f.actionInput)
+	f.tutView.tut.App.SetFocus(f.action)
+	ev := tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	f.tutView.tut.App.QueueEvent(ev)
+}
+
+func (f *FilterView) Save() {
+	fs := f.filter
+	if fs.title == "" {
+		f.tutView.ShowError("The filter needs a title.")
+		return
+	}
+	if len(fs.context) == 0 {
+		f.tutView.ShowError("The filter needs at least one context.")
+		return
+	}
+	params := &api.FilterParams{
+		Title:           fs.title,
+		Context:         fs.context,
+		FilterAction:    fs.action,
+		Keywords:        fs.keywords,
+		RemovedKeywords: fs.removed,
+	}
+	if fs.expiresAt != nil {
+		// Zero would make the filter permanent, so an expired filter has to
+		// get a new expiration before it's saved.
+		params.ExpiresIn = time.Until(*fs.expiresAt)
+		if params.ExpiresIn < time.Second {
+			f.tutView.ShowError("The filter has expired. Set a new expiration or never.")
+			return
+		}
+	}
+	var filter *api.Filter
+	var err error
+	if fs.id == "" {
+		filter, err = f.tutView.tut.Client.CreateFilter(params)
+	} else {
+		filter, err = f.tutView.tut.Client.UpdateFilter(fs.id, params)
+	}
+	if err != nil {
+		f.tutView.ShowError(
+			fmt.Sprintf("Couldn't save filter. Error: %v\n", err),
+		)
+		return
+	}
+	f.tutView.UpdateFilter(filter, fs.id == "")
+	f.tutView.FocusMainNoHistory()
+}
@@ -58,12 +58,18 @@ func (tv *TutView) Input(event *tcell.EventKey) *tcell.EventKey {
 		return tv.InputMedia(event)
 	case MediaAddFocus:
 		return tv.InputMediaAdd(event)
+	case CrossPostFocus:
+		return tv.InputCrossPost(event)
 	case VoteFocus:
 		return tv.InputVote(event)
 	case HelpFocus:
 		return tv.InputHelp(event)
 	case PreferenceFocus:
 		return tv.InputPreference(event)
+	case FilterFocus:
+		return tv.InputFilterView(event)
+	case ReportFocus:
+		return tv.InputReportView(event)
 	case EditorFocus:
 		return tv.InputEditorView(event)
 	default:
@@ -369,7 +375,7 @@ func (tv *TutView) InputItem(event *tcell.EventKey) *tcell.EventKey {
 	}
 	switch item.Type() {
 	case api.StatusType:
-		return tv.InputStatus(event, item, item.Raw().(*mastodon.Status), nil, fd.Data.Type())
+		return tv.InputStatus(event, item, item.Raw().(*mastodon.Status), nil, fd.Data.FilterType())
 	case api.StatusHistoryType:
 		return tv.InputStatusHistory(event, item, item.Raw().(*mastodon.StatusHistory), nil)
 	case api.UserType, api.ProfileType:
@@ -406,11 +412,24 @@ func (tv *TutView) InputItem(event *tcell.EventKey) *tcell.EventKey {
 			return tv.InputUser(event, nd.User.Raw().(*api.User), InputUserFollowRequest)
 		}
 	case api.ListsType:
-		ld := item.Raw().(*mastodon.List)
+		ld := item.Raw().(*api.List)
 		return tv.InputList(event, ld)
 	case api.TagType:
 		tag := item.Raw().(*mastodon.Tag)
 		return tv.InputTag(event, tag)
+	case api.ScheduledType:
+		s := item.Raw().(*api.ScheduledStatus)
+		return tv.InputScheduled(event, s)
+	case api.DraftType:
+		d := item.Raw().(*util.Draft)
+		return tv.InputDraft(event, d)
+	case api.FilterType:
+		fd := item.Raw().(*api.FilterData)
+		return tv.InputFilter(event, fd)
+	case api.DomainType:
+		return tv.InputDomain(event, item.Raw().(string))
+	case api.LinkType:
+		return tv.InputTrendLink(event, item.Raw().(*api.TrendingLink))
 	}
 	return event
 }
@@ -421,7 +440,10 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 	hasMedia := len(sr.MediaAttachments) > 0
 	hasPoll := sr.Poll != nil
 	hasSpoiler := sr.Sensitive
-	isMine := sr.Account.ID == tv.tut.Client.Me.ID
+	// In a unified timeline the toot is handled by the account that got it.
+	owner := tv.itemOwner()
+	ac := owner.tut.Client
+	isMine := sr.Account.ID == ac.Me.ID
 
 	boosted, favorited, bookmarked := false, false, false
 	if sr.Reblogged != nil {
@@ -434,6 +456,23 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		bookmarked = sr.Bookmarked.(bool)
 	}
 
+	if si, ok := item.(*api.StatusItem); ok && fd == config.Thread && si.ThreadPosition() != nil {
+		tp := si.ThreadPosition()
+		if tv.tut.Config.Input.ThreadCollapse.Match(event.Key(), event.Rune()) {
+			if tp.Replies > 0 {
+				tv.GetCurrentFeed().Data.ToggleThreadCollapse(item)
+			}
+			return nil
+		}
+		if tv.tut.Config.Input.ThreadParent.Match(event.Key(), event.Rune()) {
+			tv.GetCurrentFeed().SelectThreadStatus(tp.Parent)
+			return nil
+		}
+		if tv.tut.Config.Input.ThreadNextSibling.Match(event.Key(), event.Rune()) {
+			tv.GetCurrentFeed().SelectThreadStatus(tp.NextSibling)
+			return nil
+		}
+	}
 	if tv.tut.Config.Input.StatusAvatar.Match(event.Key(), event.Rune()) {
 		if nAcc != nil {
 			openAvatar(tv, *nAcc)
@@ -449,7 +488,7 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		}
 		tv.ModalView.Run(
 			fmt.Sprintf("Do you want to %s this toot?", txt), func() {
-				ns, err := tv.tut.Client.BoostToggle(status)
+				ns, err := ac.BoostToggle(status)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't boost toot. Error: %v\n", err),
@@ -466,7 +505,7 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 			return nil
 		}
 		tv.ModalView.Run("Do you want to delete this toot?", func() {
-			err := tv.tut.Client.DeleteStatus(sr)
+			err := ac.DeleteStatus(sr)
 			if err != nil {
 				tv.ShowError(
 					fmt.Sprintf("Couldn't delete toot. Error: %v\n", err),
@@ -485,7 +524,11 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		return nil
 	}
 	if tv.tut.Config.Input.StatusEdit.Match(event.Key(), event.Rune()) {
-		tv.EditCommand()
+		if !isMine {
+			return nil
+		}
+		TutViews.Focus(owner)
+		owner.InitPost(nil, sr)
 		return nil
 	}
 	if tv.tut.Config.Input.StatusFavorite.Match(event.Key(), event.Rune()) {
@@ -495,7 +538,7 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		}
 		tv.ModalView.Run(fmt.Sprintf("Do you want to %s this toot?", txt),
 			func() {
-				ns, err := tv.tut.Client.FavoriteToogle(status)
+				ns, err := ac.FavoriteToogle(status)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't favorite toot. Error: %v\n", err),
@@ -521,12 +564,14 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		if !hasPoll {
 			return nil
 		}
-		tv.VoteView.SetPoll(sr.Poll)
-		tv.SetPage(VoteFocus)
+		TutViews.Focus(owner)
+		owner.VoteView.SetPoll(sr.Poll)
+		owner.SetPage(VoteFocus)
 		return nil
 	}
 	if tv.tut.Config.Input.StatusReply.Match(event.Key(), event.Rune()) {
-		tv.InitPost(status, nil)
+		TutViews.Focus(owner)
+		owner.InitPost(status, nil)
 		return nil
 	}
 	if tv.tut.Config.Input.StatusBookmark.Match(event.Key(), event.Rune()) {
@@ -536,7 +581,7 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		}
 		tv.ModalView.Run(fmt.Sprintf("Do you want to %s this toot?", txt),
 			func() {
-				ns, err := tv.tut.Client.BookmarkToogle(status)
+				ns, err := ac.BookmarkToogle(status)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't bookmark toot. Error: %v\n", err),
@@ -549,7 +594,8 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		return nil
 	}
 	if tv.tut.Config.Input.StatusThread.Match(event.Key(), event.Rune()) {
-		tv.Timeline.AddFeed(NewThreadFeed(tv, item, config.NewTimeline(config.Timeline{
+		TutViews.Focus(owner)
+		owner.Timeline.AddFeed(NewThreadFeed(owner, item, config.NewTimeline(config.Timeline{
 			FeedType: config.Thread,
 		})), false)
 		return nil
@@ -559,11 +605,12 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		if nAcc != nil {
 			id = nAcc.ID
 		}
-		user, err := tv.tut.Client.GetUserByID(id)
+		user, err := ac.GetUserByID(id)
 		if err != nil {
 			return nil
 		}
-		tv.Timeline.AddFeed(NewUserFeed(tv, user, config.NewTimeline(config.Timeline{
+		TutViews.Focus(owner)
+		owner.Timeline.AddFeed(NewUserFeed(owner, user, config.NewTimeline(config.Timeline{
 			FeedType: config.User,
 		})), false)
 		return nil
@@ -576,6 +623,55 @@ func (tv *TutView) InputStatus(event *tcell.EventKey, item api.Item, status *mas
 		copyToClipboard(sr.URL)
 		return nil
 	}
+	if tv.tut.Config.Input.StatusFilter.Match(event.Key(), event.Rune()) {
+		if isMine {
+			return nil
+		}
+		TutViews.Focus(owner)
+		owner.Timeline.AddFeed(NewFiltersStatusFeed(owner, sr, config.NewTimeline(config.Timeline{
+			FeedType: config.FiltersStatus,
+		})), false)
+		return nil
+	}
+	if tv.tut.Config.Input.StatusBlockDomain.Match(event.Key(), event.Rune()) {
+		domain := api.AccountDomain(&sr.Account)
+		if domain == "" {
+			return nil
+		}
+		tv.ModalView.Run(fmt.Sprintf("Do you want to block all of %s?", domain),
+			func() {
+				err := ac.BlockDomain(domain)
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't block domain. Error: %v\n", err),
+					)
+					return
+				}
+				owner.AddDomainBlock(domain)
+			})
+		return nil
+	}
+	if tv.tut.Config.Input.StatusReport.Match(event.Key(), event.Rune()) {
+		if isMine {
+			return nil
+		}
+		TutViews.Focus(owner)
+		owner.InitReport(&sr.Account, sr)
+		return nil
+	}
+	if tv.tut.Config.Input.StatusActAs.Match(event.Key(), event.Rune()) {
+		others := tv.otherAccounts()
+		if len(others) == 0 {
+			return nil
+		}
+		tv.SetPage(CmdFocus)
+		if len(others) == 1 {
+			tv.Shared.Bottom.Cmd.View.SetText(fmt.Sprintf(":act-as %s ", others[0].accountName()))
+		} else {
+			tv.Shared.Bottom.Cmd.View.SetText(":act-as ")
+		}
+		return nil
+	}
 	if tv.tut.Config.Input.StatusToggleCW.Match(event.Key(), event.Rune()) {
 		filtered, _, _, forceView := item.Filtered(fd)
 		if filtered && !forceView {
@@ -642,6 +738,20 @@ func (tv *TutView) InputStatusHistory(event *tcell.EventKey, item api.Item, sr *
 		}
 		return nil
 	}
+	if tv.tut.Config.Input.HistoryDiff.Match(event.Key(), event.Rune()) {
+		h := item.(*api.StatusHistoryItem)
+		if h.Previous() == nil {
+			return nil
+		}
+		show := !h.ShowDiff()
+		for _, it := range tv.GetCurrentFeed().Data.List() {
+			if hi, ok := it.(*api.StatusHistoryItem); ok {
+				hi.SetShowDiff(show)
+			}
+		}
+		tv.RedrawContent()
+		return nil
+	}
 
 	return event
 }
@@ -659,6 +769,8 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 	blocking := user.Relation.Blocking
 	muting := user.Relation.Muting
 	following := user.Relation.Following
+	owner := tv.itemOwner()
+	ac := owner.tut.Client
 
 	if ut == InputUserListAdd {
 		if tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) ||
@@ -667,7 +779,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 			switch ad.(type) {
 			case *mastodon.List:
 				l := user.AdditionalData.(*mastodon.List)
-				err := tv.tut.Client.AddUserToList(user.Data, l)
+				err := ac.AddUserToList(user.Data, l)
 				if err != nil {
 					tv.ShowError(fmt.Sprintf("Couldn't add user to list. Error: %v", err))
 				}
@@ -687,7 +799,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 			switch ad.(type) {
 			case *mastodon.List:
 				l := user.AdditionalData.(*mastodon.List)
-				err := tv.tut.Client.DeleteUserFromList(user.Data, l)
+				err := ac.DeleteUserFromList(user.Data, l)
 				if err != nil {
 					tv.ShowError(fmt.Sprintf("Couldn't remove user from list. Error: %v", err))
 				}
@@ -702,7 +814,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 	if ut == InputUserFollowRequest && tv.tut.Config.Input.UserFollowRequestDecide.Match(event.Key(), event.Rune()) {
 		tv.ModalView.RunDecide("Do you want accept the follow request?",
 			func() {
-				err := tv.tut.Client.FollowRequestAccept(user.Data)
+				err := ac.FollowRequestAccept(user.Data)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't accept follow request. Error: %v\n", err),
@@ -714,7 +826,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 				tv.RedrawContent()
 			},
 			func() {
-				err := tv.tut.Client.FollowRequestDeny(user.Data)
+				err := ac.FollowRequestDeny(user.Data)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't deny follow request. Error: %v\n", err),
@@ -738,7 +850,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 		}
 		tv.ModalView.Run(fmt.Sprintf("Do you want to %s this user?", txt),
 			func() {
-				rel, err := tv.tut.Client.BlockToggle(user)
+				rel, err := ac.BlockToggle(user)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't block user. Error: %v\n", err),
@@ -750,6 +862,48 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 			})
 		return nil
 	}
+	if tv.tut.Config.Input.UserBlockDomain.Match(event.Key(), event.Rune()) {
+		domain := api.AccountDomain(user.Data)
+		if domain == "" {
+			return nil
+		}
+		domainBlocking := user.Relation.DomainBlocking
+		txt := "block"
+		if domainBlocking {
+			txt = "unblock"
+		}
+		tv.ModalView.Run(fmt.Sprintf("Do you want to %s all of %s?", txt, domain),
+			func() {
+				var err error
+				if domainBlocking {
+					err = ac.UnblockDomain(domain)
+				} else {
+					err = ac.BlockDomain(domain)
+				}
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't %s domain. Error: %v\n", txt, err),
+					)
+					return
+				}
+				user.Relation.DomainBlocking = !domainBlocking
+				if domainBlocking {
+					owner.RemoveDomainBlock(domain)
+				} else {
+					owner.AddDomainBlock(domain)
+				}
+				tv.RedrawControls()
+			})
+		return nil
+	}
+	if tv.tut.Config.Input.UserReport.Match(event.Key(), event.Rune()) {
+		if user.Data.ID == ac.Me.ID {
+			return nil
+		}
+		TutViews.Focus(owner)
+		owner.InitReport(user.Data, nil)
+		return nil
+	}
 	if tv.tut.Config.Input.UserFollow.Match(event.Key(), event.Rune()) {
 		txt := "follow"
 		if following {
@@ -757,7 +911,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 		}
 		tv.ModalView.Run(fmt.Sprintf("Do you want to %s this user?", txt),
 			func() {
-				rel, err := tv.tut.Client.FollowToggle(user)
+				rel, err := ac.FollowToggle(user)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't follow user. Error: %v\n", err),
@@ -776,7 +930,7 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 		}
 		tv.ModalView.Run(fmt.Sprintf("Do you want to %s this user?", txt),
 			func() {
-				rel, err := tv.tut.Client.MuteToggle(user)
+				rel, err := ac.MuteToggle(user)
 				if err != nil {
 					tv.ShowError(
 						fmt.Sprintf("Couldn't follow user. Error: %v\n", err),
@@ -793,7 +947,8 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 		return nil
 	}
 	if tv.tut.Config.Input.UserUser.Match(event.Key(), event.Rune()) {
-		tv.Timeline.AddFeed(NewUserFeed(tv, api.NewUserItem(user, true), config.NewTimeline(config.Timeline{
+		TutViews.Focus(owner)
+		owner.Timeline.AddFeed(NewUserFeed(owner, api.NewUserItem(user, true), config.NewTimeline(config.Timeline{
 			FeedType: config.User,
 		})), false)
 		return nil
@@ -807,7 +962,8 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 		return nil
 	}
 	if tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) {
-		tv.Timeline.AddFeed(NewUserFeed(tv, api.NewUserItem(user, true), config.NewTimeline(config.Timeline{
+		TutViews.Focus(owner)
+		owner.Timeline.AddFeed(NewUserFeed(owner, api.NewUserItem(user, true), config.NewTimeline(config.Timeline{
 			FeedType: config.User,
 		})), false)
 		return nil
@@ -815,26 +971,62 @@ func (tv *TutView) InputUser(event *tcell.EventKey, user *api.User, ut InputUser
 	return event
 }
 
-func (tv *TutView) InputList(event *tcell.EventKey, list *mastodon.List) *tcell.EventKey {
+func (tv *TutView) InputList(event *tcell.EventKey, list *api.List) *tcell.EventKey {
 	if tv.tut.Config.Input.ListOpenFeed.Match(event.Key(), event.Rune()) ||
 		tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) {
-		tv.Timeline.AddFeed(NewListFeed(tv, list, config.NewTimeline(config.Timeline{
+		tv.Timeline.AddFeed(NewListFeed(tv, &list.List, config.NewTimeline(config.Timeline{
 			FeedType: config.List,
 		})), false)
 		return nil
 	}
 	if tv.tut.Config.Input.ListUserList.Match(event.Key(), event.Rune()) {
-		tv.Timeline.AddFeed(NewUsersInListFeed(tv, list, config.NewTimeline(config.Timeline{
+		tv.Timeline.AddFeed(NewUsersInListFeed(tv, &list.List, config.NewTimeline(config.Timeline{
 			FeedType: config.ListUsersIn,
 		})), false)
 		return nil
 	}
 	if tv.tut.Config.Input.ListUserAdd.Match(event.Key(), event.Rune()) {
-		tv.Timeline.AddFeed(NewUsersAddListFeed(tv, list, config.NewTimeline(config.Timeline{
+		tv.Timeline.AddFeed(NewUsersAddListFeed(tv, &list.List, config.NewTimeline(config.Timeline{
 			FeedType: config.ListUsersAdd,
 		})), false)
 		return nil
 	}
+	if tv.tut.Config.Input.ListCreate.Match(event.Key(), event.Rune()) {
+		tv.EditListTitle(nil)
+		return nil
+	}
+	if tv.tut.Config.Input.ListRename.Match(event.Key(), event.Rune()) {
+		tv.EditListTitle(list)
+		return nil
+	}
+	if tv.tut.Config.Input.ListRepliesPolicy.Match(event.Key(), event.Rune()) {
+		next := api.ListRepliesPolicies[0]
+		for i, p := range api.ListRepliesPolicies {
+			if p == list.RepliesPolicy {
+				next = api.ListRepliesPolicies[(i+1)%len(api.ListRepliesPolicies)]
+			}
+		}
+		tv.UpdateList(list, list.Title, next, list.Exclusive)
+		return nil
+	}
+	if tv.tut.Config.Input.ListExclusive.Match(event.Key(), event.Rune()) {
+		tv.UpdateList(list, list.Title, list.RepliesPolicy, !list.Exclusive)
+		return nil
+	}
+	if tv.tut.Config.Input.ListDelete.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run(fmt.Sprintf("Do you want to delete the list %s?", list.Title),
+			func() {
+				err := tv.tut.Client.DeleteList(list)
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't delete list. Error: %v\n", err),
+					)
+					return
+				}
+				tv.RemoveList(list)
+			})
+		return nil
+	}
 	return event
 }
 
@@ -869,6 +1061,132 @@ func (tv *TutView) InputTag(event *tcell.EventKey, tag *mastodon.Tag) *tcell.Eve
 	return event
 }
 
+func (tv *TutView) InputScheduled(event *tcell.EventKey, s *api.ScheduledStatus) *tcell.EventKey {
+	if tv.tut.Config.Input.ScheduledEdit.Match(event.Key(), event.Rune()) {
+		tv.InitScheduledPost(s)
+		return nil
+	}
+	if tv.tut.Config.Input.ScheduledReschedule.Match(event.Key(), event.Rune()) {
+		tv.SetPage(CmdFocus)
+		tv.Shared.Bottom.Cmd.View.SetText(":reschedule ")
+		return nil
+	}
+	if tv.tut.Config.Input.ScheduledDelete.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run("Do you want to cancel this scheduled toot?",
+			func() {
+				err := tv.tut.Client.CancelScheduledStatus(s)
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't cancel scheduled toot. Error: %v\n", err),
+					)
+					return
+				}
+				tv.RemoveScheduled(s)
+			})
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputDraft(event *tcell.EventKey, d *util.Draft) *tcell.EventKey {
+	if tv.tut.Config.Input.DraftEdit.Match(event.Key(), event.Rune()) {
+		tv.InitDraftPost(d)
+		return nil
+	}
+	if tv.tut.Config.Input.DraftDelete.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run("Do you want to delete this draft?",
+			func() {
+				err := tv.tut.Client.DeleteDraft(d)
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't delete draft. Error: %v\n", err),
+					)
+					return
+				}
+				tv.RemoveDraft(d)
+			})
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputFilter(event *tcell.EventKey, fd *api.FilterData) *tcell.EventKey {
+	if fd.Status != nil {
+		if tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) ||
+			tv.tut.Config.Input.FilterAddStatus.Match(event.Key(), event.Rune()) {
+			tv.ModalView.Run(fmt.Sprintf("Do you want to add this toot to %s?", fd.Filter.Title),
+				func() {
+					_, err := tv.tut.Client.AddStatusToFilter(fd.Filter, fd.Status)
+					if err != nil {
+						tv.ShowError(
+							fmt.Sprintf("Couldn't add toot to filter. Error: %v\n", err),
+						)
+						return
+					}
+					tv.UpdateFilter(fd.Filter, false)
+				})
+			return nil
+		}
+		return event
+	}
+	if tv.tut.Config.Input.FilterEdit.Match(event.Key(), event.Rune()) {
+		tv.InitFilter(fd.Filter)
+		return nil
+	}
+	if tv.tut.Config.Input.FilterDelete.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run(fmt.Sprintf("Do you want to delete the filter %s?", fd.Filter.Title),
+			func() {
+				err := tv.tut.Client.DeleteFilter(fd.Filter)
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't delete filter. Error: %v\n", err),
+					)
+					return
+				}
+				tv.RemoveFilter(fd.Filter)
+			})
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputDomain(event *tcell.EventKey, domain string) *tcell.EventKey {
+	if tv.tut.Config.Input.DomainUnblock.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run(fmt.Sprintf("Do you want to unblock %s?", domain),
+			func() {
+				err := tv.tut.Client.UnblockDomain(domain)
+				if err != nil {
+					tv.ShowError(
+						fmt.Sprintf("Couldn't unblock domain. Error: %v\n", err),
+					)
+					return
+				}
+				tv.RemoveDomainBlock(domain)
+			})
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputTrendLink(event *tcell.EventKey, link *api.TrendingLink) *tcell.EventKey {
+	if tv.tut.Config.Input.TrendLinkOpen.Match(event.Key(), event.Rune()) {
+		openURL(tv, link.URL)
+		return nil
+	}
+	if tv.tut.Config.Input.TrendLinkToots.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) {
+		if !tv.tut.Client.SupportsLinkTimeline() {
+			tv.ShowError("Your server needs Mastodon 4.3 or newer to show the toots that share a link")
+			return nil
+		}
+		tv.Timeline.AddFeed(NewLinkFeed(tv, link.URL, config.NewTimeline(config.Timeline{
+			FeedType: config.Link,
+		})), false)
+		return nil
+	}
+	return event
+}
+
 func (tv *TutView) InputLinkView(event *tcell.EventKey) *tcell.EventKey {
 	if tv.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
 		tv.LinkView.Next()
@@ -946,6 +1264,18 @@ func (tv *TutView) InputComposeView(event *tcell.EventKey) *tcell.EventKey {
 		tv.ComposeView.FocusLang()
 		return nil
 	}
+	if tv.tut.Config.Input.ComposeSchedule.Match(event.Key(), event.Rune()) {
+		tv.ComposeView.FocusSchedule()
+		return nil
+	}
+	if tv.tut.Config.Input.ComposeAccounts.Match(event.Key(), event.Rune()) {
+		if !tv.ComposeView.crossPost.Enabled() {
+			tv.ShowError("You can only post a new toot to other accounts")
+			return nil
+		}
+		tv.SetPage(CrossPostFocus)
+		return nil
+	}
 	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
 		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
 		tv.ModalView.Run(
@@ -1013,6 +1343,31 @@ func (tv *TutView) InputMediaAdd(event *tcell.EventKey) *tcell.EventKey {
 	return event
 }
 
+func (tv *TutView) InputCrossPost(event *tcell.EventKey) *tcell.EventKey {
+	if tv.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
+		tv.ComposeView.crossPost.Next()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalUp.Match(event.Key(), event.Rune()) {
+		tv.ComposeView.crossPost.Prev()
+		return nil
+	}
+	if tv.tut.Config.Input.CrossPostToggle.Match(event.Key(), event.Rune()) {
+		tv.ComposeView.crossPost.Toggle()
+		return nil
+	}
+	if tv.tut.Config.Input.CrossPostVisibility.Match(event.Key(), event.Rune()) {
+		tv.ComposeView.crossPost.NextVisibility()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
+		tv.SetPage(ComposeFocus)
+		return nil
+	}
+	return event
+}
+
 func (tv *TutView) InputPollView(event *tcell.EventKey) *tcell.EventKey {
 	if tv.tut.Config.Input.PollAdd.Match(event.Key(), event.Rune()) {
 		tv.PollView.Add()
@@ -1139,6 +1494,164 @@ func (tv *TutView) InputPreferenceFields(event *tcell.EventKey) *tcell.EventKey
 	return event
 }
 
+func (tv *TutView) InputFilterView(event *tcell.EventKey) *tcell.EventKey {
+	if tv.FilterView.HasContextFocus() {
+		return tv.InputFilterContexts(event)
+	}
+	if tv.FilterView.HasKeywordFocus() {
+		return tv.InputFilterKeywords(event)
+	}
+	if tv.tut.Config.Input.FilterTitle.Match(event.Key(), event.Rune()) {
+		tv.FilterView.EditTitle()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterAction.Match(event.Key(), event.Rune()) {
+		tv.FilterView.FocusAction()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterExpires.Match(event.Key(), event.Rune()) {
+		tv.FilterView.EditExpires()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterContext.Match(event.Key(), event.Rune()) {
+		tv.FilterView.ContextFocus()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterKeywords.Match(event.Key(), event.Rune()) {
+		tv.FilterView.KeywordFocus()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterSave.Match(event.Key(), event.Rune()) {
+		tv.FilterView.Save()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run(
+			"Do you want exit the filter view?", func() {
+				tv.FocusMainNoHistory()
+			})
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputFilterContexts(event *tcell.EventKey) *tcell.EventKey {
+	if tv.tut.Config.Input.GlobalUp.Match(event.Key(), event.Rune()) {
+		tv.FilterView.Prev()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
+		tv.FilterView.Next()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.FilterContextToggle.Match(event.Key(), event.Rune()) {
+		tv.FilterView.ToggleContext()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
+		tv.FilterView.MainFocus()
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputFilterKeywords(event *tcell.EventKey) *tcell.EventKey {
+	if tv.tut.Config.Input.GlobalUp.Match(event.Key(), event.Rune()) {
+		tv.FilterView.Prev()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
+		tv.FilterView.Next()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterKeywordAdd.Match(event.Key(), event.Rune()) {
+		tv.FilterView.AddKeyword()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterKeywordEdit.Match(event.Key(), event.Rune()) {
+		tv.FilterView.EditKeyword()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterKeywordDelete.Match(event.Key(), event.Rune()) {
+		tv.FilterView.DeleteKeyword()
+		return nil
+	}
+	if tv.tut.Config.Input.FilterKeywordWholeWord.Match(event.Key(), event.Rune()) {
+		tv.FilterView.ToggleWholeWord()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
+		tv.FilterView.MainFocus()
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputReportView(event *tcell.EventKey) *tcell.EventKey {
+	if tv.ReportView.HasRulesFocus() || tv.ReportView.HasTootsFocus() {
+		return tv.InputReportList(event)
+	}
+	if tv.tut.Config.Input.ReportCategory.Match(event.Key(), event.Rune()) {
+		tv.ReportView.FocusCategory()
+		return nil
+	}
+	if tv.tut.Config.Input.ReportRules.Match(event.Key(), event.Rune()) {
+		tv.ReportView.RulesFocus()
+		return nil
+	}
+	if tv.tut.Config.Input.ReportToots.Match(event.Key(), event.Rune()) {
+		tv.ReportView.TootsFocus()
+		return nil
+	}
+	if tv.tut.Config.Input.ReportComment.Match(event.Key(), event.Rune()) {
+		tv.ReportView.EditComment()
+		return nil
+	}
+	if tv.tut.Config.Input.ReportForward.Match(event.Key(), event.Rune()) {
+		tv.ReportView.ToggleForward()
+		return nil
+	}
+	if tv.tut.Config.Input.ReportSend.Match(event.Key(), event.Rune()) {
+		tv.ReportView.Send()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
+		tv.ModalView.Run(
+			"Do you want exit the report view?", func() {
+				tv.FocusMainNoHistory()
+			})
+		return nil
+	}
+	return event
+}
+
+func (tv *TutView) InputReportList(event *tcell.EventKey) *tcell.EventKey {
+	if tv.tut.Config.Input.GlobalUp.Match(event.Key(), event.Rune()) {
+		tv.ReportView.Prev()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
+		tv.ReportView.Next()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalEnter.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.ReportToggle.Match(event.Key(), event.Rune()) {
+		tv.ReportView.Toggle()
+		return nil
+	}
+	if tv.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) ||
+		tv.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) {
+		tv.ReportView.MainFocus()
+		return nil
+	}
+	return event
+}
+
 func (tv *TutView) InputCmdView(event *tcell.EventKey) *tcell.EventKey {
 	switch event.Key() {
 	case tcell.KeyEnter:
@@ -1201,12 +1714,16 @@ func (tv *TutView) MouseInput(event *tcell.EventMouse, action tview.MouseAction)
 		return tv.MouseInputComposeView(event, action)
 	case PreferenceFocus:
 		return tv.MouseInputPreferenceView(event, action)
+	case FilterFocus:
+		return tv.MouseInputFilterView(event, action)
+	case ReportFocus:
+		return tv.MouseInputReportView(event, action)
 	}
 
 	return nil, action
 }
 
-func (tv *TutView) feedListMouse(list *tview.List, i int, event *tcell.EventMouse, action tview.MouseAction) {
+func (tv *TutView) feedListMouse(fl *FeedList, list *tview.List, i int, event *tcell.EventMouse, action tview.MouseAction) {
 	tv.SetPage(MainFocus)
 	tv.FocusFeed(i, nil)
 	mh := list.MouseHandler()
@@ -1217,7 +1734,7 @@ func (tv *TutView) feedListMouse(list *tview.List, i int, event *tcell.EventMous
 	mh(action, event, func(p tview.Primitive) {})
 	newIndex := list.GetCurrentItem()
 	if lastIndex != newIndex {
-		tv.Timeline.SetItemFeedIndex(newIndex)
+		tv.Timeline.SetItemFeedIndex(fl.ItemIndex(newIndex))
 	}
 }
 
@@ -1298,11 +1815,11 @@ func (tv *TutView) MouseInputMainView(event *tcell.EventMouse, action tview.Mous
 		for i, tl := range tv.Timeline.Feeds {
 			fl := tl.GetFeedList()
 			if fl.Text.InRect(x, y) {
-				tv.feedListMouse(fl.Text, i, event, action)
+				tv.feedListMouse(fl, fl.Text, i, event, action)
 				return nil, action
 			}
 			if fl.Symbol.InRect(x, y) {
-				tv.feedListMouse(fl.Symbol, i, event, action)
+				tv.feedListMouse(fl, fl.Symbol, i, event, action)
 				return nil, action
 			}
 		}
@@ -1453,6 +1970,28 @@ func (tv *TutView) MouseInputPreferenceView(event *tcell.EventMouse, action tvie
 	return nil, action
 }
 
+func (tv *TutView) MouseInputFilterView(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	x, y := event.Position()
+	switch action {
+	case tview.MouseLeftClick:
+		if tv.FilterView.controls.InRect(x, y) {
+			return event, action
+		}
+	}
+	return nil, action
+}
+
+func (tv *TutView) MouseInputReportView(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
+	x, y := event.Position()
+	switch action {
+	case tview.MouseLeftClick:
+		if tv.ReportView.controls.InRect(x, y) {
+			return event, action
+		}
+	}
+	return nil, action
+}
+
 func (tv *TutView) MouseInputComposeView(event *tcell.EventMouse, action tview.MouseAction) (*tcell.EventMouse, tview.MouseAction) {
 	x, y := event.Position()
 	switch action {
@@ -8,11 +8,23 @@ import (
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
 	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/util"
 	"github.com/icza/gox/timex"
 	"github.com/rivo/tview"
 )
 
-func DrawListItem(cfg *config.Config, item api.Item) (string, string) {
+// DrawListItem returns the text and symbol of item in a list. The badge is the
+// account that received the item in a unified timeline, it's empty in all
+// other timelines.
+func DrawListItem(cfg *config.Config, item api.Item, badge string) (string, string) {
+	main, symbol := drawListItem(cfg, item)
+	if badge != "" {
+		main = fmt.Sprintf("%s %s", main, config.SublteText(cfg, tview.Escape("@"+badge)))
+	}
+	return main, symbol
+}
+
+func drawListItem(cfg *config.Config, item api.Item) (string, string) {
 	switch item.Type() {
 	case api.StatusType:
 		s := item.Raw().(*mastodon.Status)
@@ -35,6 +47,13 @@ func DrawListItem(cfg *config.Config, item api.Item) (string, string) {
 			acc = fmt.Sprintf("♺ %s", acc)
 		}
 		d := OutputDate(cfg, s.CreatedAt.Local())
+		if si, ok := item.(*api.StatusItem); ok && si.ThreadPosition() != nil {
+			tp := si.ThreadPosition()
+			if tp.Collapsed {
+				acc = fmt.Sprintf("%s (+%d)", acc, tp.Descendants)
+			}
+			return fmt.Sprintf("%s%s %s", tp.Prefix, d, acc), symbol
+		}
 		return fmt.Sprintf("%s %s", d, acc), symbol
 	case api.StatusHistoryType:
 		s := item.Raw().(*mastodon.StatusHistory)
@@ -68,11 +87,32 @@ func DrawListItem(cfg *config.Config, item api.Item) (string, string) {
 		d := OutputDate(cfg, a.Item.CreatedAt.Local())
 		return fmt.Sprintf("%s %s", d, strings.TrimSpace(a.Item.Account.Acct)), symbol
 	case api.ListsType:
-		a := item.Raw().(*mastodon.List)
+		a := item.Raw().(*api.List)
 		return tview.Escape(a.Title), ""
 	case api.TagType:
 		a := item.Raw().(*mastodon.Tag)
 		return tview.Escape("#" + a.Name), ""
+	case api.ScheduledType:
+		a := item.Raw().(*api.ScheduledStatus)
+		text := strings.TrimSpace(strings.SplitN(a.Params.Text, "\n", 2)[0])
+		return fmt.Sprintf("%s %s", a.ScheduledAt.Local().Format("2006-01-02 15:04"), tview.Escape(text)), ""
+	case api.DraftType:
+		a := item.Raw().(*util.Draft)
+		text := strings.TrimSpace(strings.SplitN(a.Text, "\n", 2)[0])
+		d := OutputDate(cfg, a.UpdatedAt.Local())
+		symbol := ""
+		if a.InReplyToID != "" {
+			symbol = " ⤶ "
+		}
+		return fmt.Sprintf("%s %s", d, tview.Escape(text)), symbol
+	case api.FilterType:
+		a := item.Raw().(*api.FilterData)
+		return tview.Escape(a.Filter.Title), ""
+	case api.DomainType:
+		return tview.Escape(item.Raw().(string)), ""
+	case api.LinkType:
+		a := item.Raw().(*api.TrendingLink)
+		return tview.Escape(a.Title), ""
 	default:
 		return "", ""
 	}
@@ -83,18 +123,7 @@ func DrawItem(tv *TutView, item api.Item, main *tview.TextView, controls *tview.
 	case api.StatusType:
 		drawStatus(tv, item, item.Raw().(*mastodon.Status), main, controls, ft, false, "")
 	case api.StatusHistoryType:
-		s := item.Raw().(*mastodon.StatusHistory)
-		status := mastodon.Status{
-			Content:          s.Content,
-			SpoilerText:      s.SpoilerText,
-			Account:          s.Account,
-			Sensitive:        s.Sensitive,
-			CreatedAt:        s.CreatedAt,
-			Emojis:           s.Emojis,
-			MediaAttachments: s.MediaAttachments,
-			Visibility:       mastodon.VisibilityPublic,
-		}
-		drawStatus(tv, item, &status, main, controls, ft, true, "")
+		drawStatusHistory(tv, item, main, controls, ft)
 	case api.UserType, api.ProfileType:
 		switch ft {
 		case config.FollowRequests:
@@ -109,9 +138,19 @@ func DrawItem(tv *TutView, item api.Item, main *tview.TextView, controls *tview.
 	case api.NotificationType:
 		drawNotification(tv, item, item.Raw().(*api.NotificationData), main, controls)
 	case api.ListsType:
-		drawList(tv, item.Raw().(*mastodon.List), main, controls)
+		drawList(tv, item.Raw().(*api.List), main, controls)
 	case api.TagType:
 		drawTag(tv, item.Raw().(*mastodon.Tag), main, controls)
+	case api.ScheduledType:
+		drawScheduled(tv, item.Raw().(*api.ScheduledStatus), main, controls)
+	case api.DraftType:
+		drawDraft(tv, item.Raw().(*util.Draft), main, controls)
+	case api.FilterType:
+		drawFilter(tv, item.Raw().(*api.FilterData), main, controls)
+	case api.DomainType:
+		drawDomain(tv, item.Raw().(string), main, controls)
+	case api.LinkType:
+		drawLink(tv, item.Raw().(*api.TrendingLink), main, controls)
 	}
 }
 
@@ -120,18 +159,7 @@ func DrawItemControls(tv *TutView, item api.Item, controls *tview.Flex, ft confi
 	case api.StatusType:
 		drawStatus(tv, item, item.Raw().(*mastodon.Status), nil, controls, ft, false, "")
 	case api.StatusHistoryType:
-		s := item.Raw().(*mastodon.StatusHistory)
-		status := mastodon.Status{
-			Content:          s.Content,
-			SpoilerText:      s.SpoilerText,
-			Account:          s.Account,
-			Sensitive:        s.Sensitive,
-			CreatedAt:        s.CreatedAt,
-			Emojis:           s.Emojis,
-			MediaAttachments: s.MediaAttachments,
-			Visibility:       mastodon.VisibilityPublic,
-		}
-		drawStatus(tv, item, &status, nil, controls, ft, true, "")
+		drawStatusHistory(tv, item, nil, controls, ft)
 	case api.UserType, api.ProfileType:
 		if ft == config.FollowRequests {
 			drawUser(tv, item.Raw().(*api.User), nil, controls, "", InputUserFollowRequest)
@@ -141,9 +169,19 @@ func DrawItemControls(tv *TutView, item api.Item, controls *tview.Flex, ft confi
 	case api.NotificationType:
 		drawNotification(tv, item, item.Raw().(*api.NotificationData), nil, controls)
 	case api.ListsType:
-		drawList(tv, item.Raw().(*mastodon.List), nil, controls)
+		drawList(tv, item.Raw().(*api.List), nil, controls)
 	case api.TagType:
 		drawTag(tv, item.Raw().(*mastodon.Tag), nil, controls)
+	case api.ScheduledType:
+		drawScheduled(tv, item.Raw().(*api.ScheduledStatus), nil, controls)
+	case api.DraftType:
+		drawDraft(tv, item.Raw().(*util.Draft), nil, controls)
+	case api.FilterType:
+		drawFilter(tv, item.Raw().(*api.FilterData), nil, controls)
+	case api.DomainType:
+		drawDomain(tv, item.Raw().(string), nil, controls)
+	case api.LinkType:
+		drawLink(tv, item.Raw().(*api.TrendingLink), nil, controls)
 	}
 
 }
@@ -0,0 +1,23 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/rivo/tview"
+)
+
+func drawDomain(tv *TutView, domain string, main *tview.TextView, controls *tview.Flex) {
+	controls.Clear()
+	item := NewControl(tv.tut.Config, tv.tut.Config.Input.DomainUnblock, true)
+	controls.AddItem(NewControlButton(tv, item), item.Len, 0, false)
+	if main == nil {
+		return
+	}
+	special := config.ColorMark(tv.tut.Config.Style.TextSpecial1)
+	subtle := config.ColorMark(tv.tut.Config.Style.Subtle)
+	out := fmt.Sprintf("%s%s\n\n", special, tview.Escape(domain))
+	out += fmt.Sprintf("%sYou won't see toots or notifications from this domain and your followers on it have been removed.", subtle)
+	main.SetText(out)
+	main.ScrollToBeginning()
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/util"
+	"github.com/rivo/tview"
+)
+
+func drawDraft(tv *TutView, data *util.Draft, main *tview.TextView, controls *tview.Flex) {
+	controls.Clear()
+	var items []Control
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.DraftEdit, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.DraftDelete, true))
+	for i, item := range items {
+		if i < len(items)-1 {
+			controls.AddItem(NewControlButton(tv, item), item.Len+1, 0, false)
+		} else {
+			controls.AddItem(NewControlButton(tv, item), item.Len, 0, false)
+		}
+	}
+	if main == nil {
+		return
+	}
+	normal := config.ColorMark(tv.tut.Config.Style.Text)
+	subtle := config.ColorMark(tv.tut.Config.Style.Subtle)
+	special := config.ColorMark(tv.tut.Config.Style.TextSpecial1)
+
+	out := fmt.Sprintf("%sDraft saved %s%s\n", subtle, special, data.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	if data.Visibility != "" {
+		out += fmt.Sprintf("%sVisibility: %s%s\n", subtle, normal, data.Visibility)
+	}
+	if data.InReplyToID != "" {
+		out += fmt.Sprintf("%sReply to toot %s\n", subtle, data.InReplyToID)
+	}
+	out += "\n"
+	if data.Sensitive || data.CWText != "" {
+		out += fmt.Sprintf("%s%s\n\n%s---hidden content below---\n\n", normal, tview.Escape(data.CWText), subtle)
+	}
+	out += normal + tview.Escape(data.Text) + "\n"
+	if data.Poll != nil {
+		out += fmt.Sprintf("\n%sPoll%s\n", subtle, normal)
+		for _, o := range data.Poll.Options {
+			out += tview.Escape(o) + "\n"
+		}
+	}
+	for _, m := range data.Media {
+		out += fmt.Sprintf("\n%sAttached %s%s\n", subtle, tview.Escape(filepath.Base(m.Path)), normal)
+		if m.Description != "" {
+			out += tview.Escape(m.Description) + "\n"
+		}
+	}
+	main.SetText(out)
+	main.ScrollToBeginning()
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/rivo/tview"
+)
+
+func drawFilter(tv *TutView, data *api.FilterData, main *tview.TextView, controls *tview.Flex) {
+	controls.Clear()
+	var items []Control
+	if data.Status != nil {
+		items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.FilterAddStatus, true))
+	} else {
+		items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.FilterEdit, true))
+		items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.FilterDelete, true))
+	}
+	for i, item := range items {
+		if i < len(items)-1 {
+			controls.AddItem(NewControlButton(tv, item), item.Len+1, 0, false)
+		} else {
+			controls.AddItem(NewControlButton(tv, item), item.Len, 0, false)
+		}
+	}
+	if main == nil {
+		return
+	}
+	normal := config.ColorMark(tv.tut.Config.Style.Text)
+	subtle := config.ColorMark(tv.tut.Config.Style.Subtle)
+	special := config.ColorMark(tv.tut.Config.Style.TextSpecial1)
+
+	f := data.Filter
+	out := fmt.Sprintf("%s%s\n\n", special, tview.Escape(f.Title))
+	out += fmt.Sprintf("%sAction: %s%s\n", subtle, normal, f.FilterAction)
+	out += fmt.Sprintf("%sContext: %s%s\n", subtle, normal, strings.Join(f.Context, ", "))
+	expires := "never"
+	if f.ExpiresAt != nil {
+		expires = f.ExpiresAt.Local().Format("2006-01-02 15:04")
+	}
+	out += fmt.Sprintf("%sExpires: %s%s\n", subtle, normal, expires)
+	out += fmt.Sprintf("\n%sKeywords%s\n", subtle, normal)
+	for _, k := range f.Keywords {
+		out += tview.Escape(k.Keyword)
+		if k.WholeWord {
+			out += fmt.Sprintf(" %s(whole word)%s", subtle, normal)
+		}
+		out += "\n"
+	}
+	if len(f.Statuses) > 0 {
+		out += fmt.Sprintf("\n%sFiltered toots: %s%d\n", subtle, normal, len(f.Statuses))
+	}
+	main.SetText(out)
+	main.ScrollToBeginning()
+}
@@ -0,0 +1,136 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/util"
+	"github.com/rivo/tview"
+)
+
+func historyStatus(s *mastodon.StatusHistory) *mastodon.Status {
+	return &mastodon.Status{
+		Content:          s.Content,
+		SpoilerText:      s.SpoilerText,
+		Account:          s.Account,
+		Sensitive:        s.Sensitive,
+		CreatedAt:        s.CreatedAt,
+		Emojis:           s.Emojis,
+		MediaAttachments: s.MediaAttachments,
+		Visibility:       mastodon.VisibilityPublic,
+	}
+}
+
+func drawStatusHistory(tv *TutView, item api.Item, main *tview.TextView, controls *tview.Flex, ft config.FeedType) {
+	h := item.(*api.StatusHistoryItem)
+	status := historyStatus(h.Raw().(*mastodon.StatusHistory))
+	if main == nil || !h.ShowDiff() {
+		drawStatus(tv, item, status, main, controls, ft, true, "")
+		return
+	}
+	drawStatus(tv, item, status, nil, controls, ft, true, "")
+	cwToggle := NewControl(tv.tut.Config, tv.tut.Config.Input.StatusToggleCW, true)
+	main.SetText(historyDiff(tv.tut.Config, h.Previous(), h, cwToggle.Label))
+	main.ScrollToBeginning()
+}
+
+func diffText(cnf *config.Config, parts []util.DiffPart) string {
+	text := config.ColorMark(cnf.Style.Text)
+	ins := config.ColorMark(cnf.Style.DiffInsert) + config.TextFlags("u")
+	del := config.ColorMark(cnf.Style.DiffDelete) + config.TextFlags("s")
+	var sb strings.Builder
+	for _, p := range parts {
+		switch p.Op {
+		case util.DiffInsert:
+			sb.WriteString(ins + tview.Escape(p.Text) + config.TextFlags("-") + text)
+		case util.DiffDelete:
+			sb.WriteString(del + tview.Escape(p.Text) + config.TextFlags("-") + text)
+		default:
+			sb.WriteString(tview.Escape(p.Text))
+		}
+	}
+	return sb.String()
+}
+
+func historyDiff(cnf *config.Config, prev *api.StatusHistoryItem, curr *api.StatusHistoryItem, cwLabel string) string {
+	a := prev.Raw().(*mastodon.StatusHistory)
+	b := curr.Raw().(*mastodon.StatusHistory)
+	text := config.ColorMark(cnf.Style.Text)
+	subtle := config.ColorMark(cnf.Style.Subtle)
+	ins := config.ColorMark(cnf.Style.DiffInsert)
+	del := config.ColorMark(cnf.Style.DiffDelete)
+	heading := func(s string) string {
+		return fmt.Sprintf("%s%s%s%s\n", subtle, config.TextFlags("b"), s, config.TextFlags("-"))
+	}
+
+	out := fmt.Sprintf("%sChanges since the version from %s\n\n", subtle, a.CreatedAt.Local().Format("2006-01-02 15:04"))
+	if a.Sensitive != b.Sensitive {
+		if b.Sensitive {
+			out += fmt.Sprintf("%sMarked as sensitive\n\n", ins)
+		} else {
+			out += fmt.Sprintf("%sNo longer marked as sensitive\n\n", del)
+		}
+	}
+	if a.SpoilerText != "" || b.SpoilerText != "" {
+		out += heading("Content warning")
+		out += text + diffText(cnf, util.DiffWords(a.SpoilerText, b.SpoilerText)) + "\n\n"
+	}
+	if b.Sensitive && !curr.ShowCW() {
+		return out + subtle + cwLabel
+	}
+	ac, _ := util.CleanHTML(a.Content)
+	bc, _ := util.CleanHTML(b.Content)
+	out += text + diffText(cnf, util.DiffWords(strings.TrimSpace(ac), strings.TrimSpace(bc))) + "\n"
+
+	pa, pb := prev.PollOptions(), curr.PollOptions()
+	if len(pa) > 0 || len(pb) > 0 {
+		out += "\n" + heading("Poll")
+		for _, p := range util.DiffList(pa, pb) {
+			switch p.Op {
+			case util.DiffInsert:
+				out += fmt.Sprintf("%s+ %s%s%s\n", ins, config.TextFlags("u"), tview.Escape(p.Text), config.TextFlags("-"))
+			case util.DiffDelete:
+				out += fmt.Sprintf("%s- %s%s%s\n", del, config.TextFlags("s"), tview.Escape(p.Text), config.TextFlags("-"))
+			default:
+				out += fmt.Sprintf("%s  %s\n", text, tview.Escape(p.Text))
+			}
+		}
+	}
+
+	before := make(map[mastodon.ID]mastodon.Attachment)
+	for _, m := range a.MediaAttachments {
+		before[m.ID] = m
+	}
+	after := make(map[mastodon.ID]bool)
+	for _, m := range b.MediaAttachments {
+		after[m.ID] = true
+	}
+	for _, m := range a.MediaAttachments {
+		if after[m.ID] {
+			continue
+		}
+		out += "\n" + heading(fmt.Sprintf("Removed %s", m.Type))
+		if m.Description == "" {
+			continue
+		}
+		out += fmt.Sprintf("%s%s%s%s\n", del, config.TextFlags("s"), tview.Escape(m.Description), config.TextFlags("-"))
+	}
+	for _, m := range b.MediaAttachments {
+		old, ok := before[m.ID]
+		switch {
+		case !ok:
+			out += "\n" + heading(fmt.Sprintf("Added %s", m.Type))
+			if m.Description == "" {
+				continue
+			}
+			out += fmt.Sprintf("%s%s%s%s\n", ins, config.TextFlags("u"), tview.Escape(m.Description), config.TextFlags("-"))
+		case old.Description != m.Description:
+			out += "\n" + heading(fmt.Sprintf("Changed description of %s", m.Type))
+			out += text + diffText(cnf, util.DiffWords(old.Description, m.Description)) + "\n"
+		}
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/rivo/tview"
+)
+
+func drawLink(tv *TutView, link *api.TrendingLink, main *tview.TextView, controls *tview.Flex) {
+	controls.Clear()
+	var items []Control
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.TrendLinkOpen, true))
+	if tv.tut.Client.SupportsLinkTimeline() {
+		items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.TrendLinkToots, true))
+	}
+	for i, item := range items {
+		if i < len(items)-1 {
+			controls.AddItem(NewControlButton(tv, item), item.Len+1, 0, false)
+		} else {
+			controls.AddItem(NewControlButton(tv, item), item.Len, 0, false)
+		}
+	}
+	if main == nil {
+		return
+	}
+	text := config.ColorMark(tv.tut.Config.Style.Text)
+	special := config.ColorMark(tv.tut.Config.Style.TextSpecial1)
+	subtle := config.ColorMark(tv.tut.Config.Style.Subtle)
+
+	out := fmt.Sprintf("%s%s\n", special, tview.Escape(link.Title))
+	if link.ProviderName != "" {
+		out += fmt.Sprintf("%s%s\n", subtle, tview.Escape(link.ProviderName))
+	}
+	if link.AuthorName != "" {
+		out += fmt.Sprintf("%sBy %s\n", subtle, tview.Escape(link.AuthorName))
+	}
+	out += "\n"
+	if link.Description != "" {
+		out += fmt.Sprintf("%s%s\n\n", text, tview.Escape(link.Description))
+	}
+	out += fmt.Sprintf("%s%s\n\n", subtle, tview.Escape(link.URL))
+	for _, h := range link.History {
+		i, err := strconv.ParseInt(h.Day, 10, 64)
+		if err != nil {
+			continue
+		}
+		tm := time.Unix(i, 0)
+		out += fmt.Sprintf("%s%s: %s accounts and %s toots\n",
+			text, tm.Format("2006-01-02"), h.Accounts, h.Uses)
+	}
+	main.SetText(out)
+	main.ScrollToBeginning()
+}
@@ -3,19 +3,25 @@ package ui
 import (
 	"fmt"
 
-	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
 	"github.com/rivo/tview"
 )
 
 type List struct {
 }
 
-func drawList(tv *TutView, data *mastodon.List, main *tview.TextView, controls *tview.Flex) {
+func drawList(tv *TutView, data *api.List, main *tview.TextView, controls *tview.Flex) {
 	controls.Clear()
 	var items []Control
 	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListOpenFeed, true))
 	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListUserList, true))
 	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListUserAdd, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListRename, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListRepliesPolicy, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListExclusive, !data.Exclusive))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListDelete, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ListCreate, true))
 	controls.Clear()
 	for i, item := range items {
 		if i < len(items)-1 {
@@ -25,7 +31,24 @@ func drawList(tv *TutView, data *mastodon.List, main *tview.TextView, controls *
 		}
 	}
 
-	if main != nil {
-		main.SetText(fmt.Sprintf("List %s", tview.Escape(data.Title)))
+	if main == nil {
+		return
 	}
+	normal := config.ColorMark(tv.tut.Config.Style.Text)
+	subtle := config.ColorMark(tv.tut.Config.Style.Subtle)
+	special := config.ColorMark(tv.tut.Config.Style.TextSpecial1)
+
+	out := fmt.Sprintf("%sList %s\n\n", special, tview.Escape(data.Title))
+	policy := data.RepliesPolicy
+	if policy == "" {
+		policy = "unknown"
+	}
+	out += fmt.Sprintf("%sReplies shown: %s%s\n", subtle, normal, policy)
+	exclusive := "no"
+	if data.Exclusive {
+		exclusive = "yes"
+	}
+	out += fmt.Sprintf("%sExclusive: %s%s\n", subtle, normal, exclusive)
+	main.SetText(out)
+	main.ScrollToBeginning()
 }
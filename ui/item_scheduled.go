@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/rivo/tview"
+)
+
+func drawScheduled(tv *TutView, data *api.ScheduledStatus, main *tview.TextView, controls *tview.Flex) {
+	controls.Clear()
+	var items []Control
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ScheduledEdit, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ScheduledReschedule, true))
+	items = append(items, NewControl(tv.tut.Config, tv.tut.Config.Input.ScheduledDelete, true))
+	for i, item := range items {
+		if i < len(items)-1 {
+			controls.AddItem(NewControlButton(tv, item), item.Len+1, 0, false)
+		} else {
+			controls.AddItem(NewControlButton(tv, item), item.Len, 0, false)
+		}
+	}
+	if main == nil {
+		return
+	}
+	normal := config.ColorMark(tv.tut.Config.Style.Text)
+	subtle := config.ColorMark(tv.tut.Config.Style.Subtle)
+	special := config.ColorMark(tv.tut.Config.Style.TextSpecial1)
+
+	p := data.Params
+	out := fmt.Sprintf("%sScheduled for %s%s\n", subtle, special, data.ScheduledAt.Local().Format("2006-01-02 15:04"))
+	if p.Visibility != "" {
+		out += fmt.Sprintf("%sVisibility: %s%s\n", subtle, normal, p.Visibility)
+	}
+	if p.InReplyToID != "" {
+		out += fmt.Sprintf("%sReply to toot %s\n", subtle, p.InReplyToID)
+	}
+	out += "\n"
+	if p.IsSensitive() || p.SpoilerText != "" {
+		out += fmt.Sprintf("%s%s\n\n%s---hidden content below---\n\n", normal, tview.Escape(p.SpoilerText), subtle)
+	}
+	out += normal + tview.Escape(p.Text) + "\n"
+	if p.Poll != nil {
+		out += fmt.Sprintf("\n%sPoll%s\n", subtle, normal)
+		for _, o := range p.Poll.Options {
+			out += tview.Escape(o) + "\n"
+		}
+	}
+	for _, m := range data.MediaAttachments {
+		out += fmt.Sprintf("\n%sAttached %s%s\n", subtle, m.Type, normal)
+		if m.Description != "" {
+			out += tview.Escape(m.Description) + "\n"
+		}
+	}
+	main.SetText(out)
+	main.ScrollToBeginning()
+}
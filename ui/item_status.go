@@ -256,6 +256,33 @@ func drawStatus(tv *TutView, item api.Item, status *mastodon.Status, main *tview
 	if !isHistory {
 		info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.StatusYank, true))
 	}
+	if status.Account.ID != tv.tut.Client.Me.ID && !isHistory {
+		info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.StatusFilter, true))
+	}
+	if api.AccountDomain(&status.Account) != "" && !isHistory {
+		info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.StatusBlockDomain, true))
+	}
+	if status.Account.ID != tv.tut.Client.Me.ID && !isHistory {
+		info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.StatusReport, true))
+	}
+	if len(tv.otherAccounts()) > 0 && !isHistory {
+		info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.StatusActAs, true))
+	}
+	if h, ok := item.(*api.StatusHistoryItem); ok && h.Previous() != nil {
+		info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.HistoryDiff, !h.ShowDiff()))
+	}
+	if si, ok := item.(*api.StatusItem); ok && ft == config.Thread && si.ThreadPosition() != nil {
+		tp := si.ThreadPosition()
+		if tp.Replies > 0 {
+			info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.ThreadCollapse, !tp.Collapsed))
+		}
+		if tp.Parent != "" {
+			info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.ThreadParent, true))
+		}
+		if tp.NextSibling != "" {
+			info = append(info, NewControl(tv.tut.Config, tv.tut.Config.Input.ThreadNextSibling, true))
+		}
+	}
 
 	for i, item := range info {
 		if i < len(info)-1 {
@@ -101,6 +101,10 @@ func drawUser(tv *TutView, data *api.User, main *tview.TextView, controls *tview
 		if len(urls) > 0 {
 			controlItems = append(controlItems, NewControl(tv.tut.Config, tv.tut.Config.Input.UserLinks, true))
 		}
+		if api.AccountDomain(user) != "" {
+			controlItems = append(controlItems, NewControl(tv.tut.Config, tv.tut.Config.Input.UserBlockDomain, !relation.DomainBlocking))
+		}
+		controlItems = append(controlItems, NewControl(tv.tut.Config, tv.tut.Config.Input.UserReport, true))
 	}
 	if showUserControl {
 		controlItems = append(controlItems, NewControl(tv.tut.Config, tv.tut.Config.Input.UserUser, true))
@@ -8,9 +8,10 @@ import (
 )
 
 type MainView struct {
-	View    *tview.Flex
-	accView *tview.Flex
-	update  chan bool
+	View       *tview.Flex
+	accView    *tview.Flex
+	accButtons []*tview.Button
+	update     chan bool
 }
 
 func NewMainView(tv *TutView, update chan bool) *MainView {
@@ -59,12 +60,14 @@ func (mv *MainView) mainViewUI(tv *TutView) *tview.Flex {
 	if tv.tut.Config.General.ListSplit == config.ListColumn {
 		feeds := tview.NewFlex()
 		for _, fh := range tv.Timeline.Feeds {
+			fh.header = nil
 			fTitle := fh.GetTitle()
 			if len(fTitle) > 0 {
 				txt := NewTextView(tv.tut.Config)
-				txt.SetText(tview.Escape(fTitle))
+				txt.SetText(tview.Escape(fh.headerText()))
 				txt.SetBackgroundColor(tv.tut.Config.Style.TimelineNameBackground)
 				txt.SetTextColor(tv.tut.Config.Style.TimelineNameText)
+				fh.header = txt
 				feeds.AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
 					AddItem(txt, 1, 0, false).
 					AddItem(feedList(tv, fh), 0, 1, false), 0, 1, false)
@@ -77,12 +80,14 @@ func (mv *MainView) mainViewUI(tv *TutView) *tview.Flex {
 	} else {
 		feeds := tview.NewFlex().SetDirection(tview.FlexRow)
 		for _, fh := range tv.Timeline.Feeds {
+			fh.header = nil
 			fTitle := fh.GetTitle()
 			if len(fTitle) > 0 {
 				txt := NewTextView(tv.tut.Config)
-				txt.SetText(tview.Escape(fTitle))
+				txt.SetText(tview.Escape(fh.headerText()))
 				txt.SetBackgroundColor(tv.tut.Config.Style.TimelineNameBackground)
 				txt.SetTextColor(tv.tut.Config.Style.TimelineNameText)
+				fh.header = txt
 				feeds.AddItem(txt, 1, 0, false)
 			}
 			feeds.AddItem(feedList(tv, fh), 0, 1, false)
@@ -95,14 +100,12 @@ func (mv *MainView) mainViewUI(tv *TutView) *tview.Flex {
 	controls := fc.Controls
 
 	mv.accView.Clear()
-	for i, t := range TutViews.Views {
-		acct := t.tut.Client.Me.Acct
-		acct = fmt.Sprintf("%s ", acct)
-		if i > 0 {
-			acct = fmt.Sprintf(" %s", acct)
-		}
+	mv.accButtons = nil
+	for i := range TutViews.Views {
+		acct := accLabel(i)
 		item := NewAccButton(tv, tv.tut.Config, acct, i, i == TutViews.Current)
 		mv.accView.AddItem(item, len(acct), 0, false)
+		mv.accButtons = append(mv.accButtons, item)
 	}
 
 	r := tview.NewFlex().SetDirection(tview.FlexRow)
@@ -143,3 +146,34 @@ func (mv *MainView) mainViewUI(tv *TutView) *tview.Flex {
 	}
 	return r
 }
+
+func accLabel(index int) string {
+	t := TutViews.Views[index]
+	acct := t.tut.Client.Me.Acct
+	if unseen := t.Unseen(); unseen > 0 {
+		acct = fmt.Sprintf("%s (%d)", acct, unseen)
+	}
+	acct = fmt.Sprintf("%s ", acct)
+	if index > 0 {
+		acct = fmt.Sprintf(" %s", acct)
+	}
+	return acct
+}
+
+// updateUnseen updates the number of new items in the pane headers and the
+// account bar.
+func (mv *MainView) updateUnseen(tv *TutView) {
+	for _, fh := range tv.Timeline.Feeds {
+		if fh.header != nil {
+			fh.header.SetText(tview.Escape(fh.headerText()))
+		}
+	}
+	for i, btn := range mv.accButtons {
+		if i >= len(TutViews.Views) {
+			break
+		}
+		acct := accLabel(i)
+		btn.SetLabel(acct)
+		mv.accView.ResizeItem(btn, len(acct), 0)
+	}
+}
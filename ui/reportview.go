@@ -0,0 +1,431 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/util"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type reportFocusAt uint
+
+const (
+	reportMainFocus reportFocusAt = iota
+	reportRulesFocus
+	reportTootsFocus
+)
+
+type reportState struct {
+	account  *mastodon.Account
+	category string
+	rules    []string
+	toots    []mastodon.ID
+	comment  string
+	forward  bool
+}
+
+type ReportView struct {
+	tutView   *TutView
+	shared    *Shared
+	View      *tview.Flex
+	info      *tview.TextView
+	category  *tview.DropDown
+	rules     *tview.List
+	toots     *tview.List
+	controls  *tview.Flex
+	report    *reportState
+	allRules  []mastodon.Rule
+	statuses  []*mastodon.Status
+	focus     reportFocusAt
+	rulesDone bool
+}
+
+func NewReportView(tv *TutView) *ReportView {
+	r := &ReportView{
+		tutView:  tv,
+		shared:   tv.Shared,
+		info:     NewTextView(tv.tut.Config),
+		category: NewDropDown(tv.tut.Config),
+		rules:    NewList(tv.tut.Config),
+		toots:    NewList(tv.tut.Config),
+		controls: NewControlView(tv.tut.Config),
+		report:   &reportState{},
+	}
+	r.View = reportViewUI(r)
+	r.MainFocus()
+
+	return r
+}
+
+func reportViewUI(r *ReportView) *tview.Flex {
+	r.category.SetLabel("Category: ")
+	r.category.SetOptions(api.ReportCategories, r.categorySelected)
+
+	v := tview.NewFlex().SetDirection(tview.FlexRow)
+	if r.tutView.tut.Config.General.TerminalTitle < 2 {
+		v.AddItem(r.shared.Top.View, 1, 0, false)
+	}
+	v.AddItem(tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(r.info, 0, 1, false).
+			AddItem(r.category, 2, 0, false).
+			AddItem(r.rules, 0, 1, false), 0, 1, false).
+		AddItem(tview.NewFlex().SetDirection(tview.FlexRow).
+			AddItem(r.toots, 0, 1, false), 0, 1, false), 0, 1, false).
+		AddItem(r.controls, 1, 0, false).
+		AddItem(r.shared.Bottom.View, 2, 0, false)
+	return v
+}
+
+// SetReport starts a new report of account. If status isn't nil it's
+// included in the report.
+func (r *ReportView) SetReport(account *mastodon.Account, status *mastodon.Status) error {
+	if !r.rulesDone {
+		rules, err := r.tutView.tut.Client.GetRules()
+		if err != nil {
+			return err
+		}
+		r.allRules = rules
+		r.rulesDone = true
+	}
+	statuses, err := r.tutView.tut.Client.GetReportStatuses(account.ID)
+	if err != nil {
+		return err
+	}
+	rs := &reportState{
+		account:  account,
+		category: api.ReportCategories[0],
+	}
+	if status != nil {
+		rs.toots = append(rs.toots, status.ID)
+		found := false
+		for _, s := range statuses {
+			if s.ID == status.ID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			statuses = append([]*mastodon.Status{status}, statuses...)
+		}
+	}
+	r.report = rs
+	r.statuses = statuses
+	r.update()
+	r.rules.SetCurrentItem(0)
+	r.toots.SetCurrentItem(0)
+	r.MainFocus()
+	return nil
+}
+
+func (r *ReportView) isRemote() bool {
+	return api.AccountDomain(r.report.account) != ""
+}
+
+func (r *ReportView) hasRule(id string) bool {
+	for _, rule := range r.report.rules {
+		if rule == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReportView) hasToot(id mastodon.ID) bool {
+	for _, toot := range r.report.toots {
+		if toot == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ReportView) update() {
+	rs := r.report
+	out := fmt.Sprintf("Report: @%s\n\n", tview.Escape(rs.account.Acct))
+	comment := rs.comment
+	if comment == "" {
+		comment = "-"
+	}
+	out += fmt.Sprintf("Comment: %s\n", tview.Escape(comment))
+	if r.isRemote() {
+		forward := "no"
+		if rs.forward {
+			forward = "yes"
+		}
+		out += fmt.Sprintf("Forward to %s: %s\n", tview.Escape(api.AccountDomain(rs.account)), forward)
+	}
+	r.info.SetText(out)
+
+	for i, c := range api.ReportCategories {
+		if c == rs.category {
+			r.category.SetCurrentOption(i)
+			break
+		}
+	}
+
+	ri := r.rules.GetCurrentItem()
+	r.rules.Clear()
+	if rs.category == "violation" {
+		for _, rule := range r.allRules {
+			check := "[ ]"
+			if r.hasRule(rule.ID) {
+				check = "[x]"
+			}
+			r.rules.AddItem(tview.Escape(fmt.Sprintf("%s %s", check, rule.Text)), "", 0, nil)
+		}
+		if ri < r.rules.GetItemCount() {
+			r.rules.SetCurrentItem(ri)
+		}
+	}
+
+	ti := r.toots.GetCurrentItem()
+	r.toots.Clear()
+	for _, s := range r.statuses {
+		check := "[ ]"
+		if r.hasToot(s.ID) {
+			check = "[x]"
+		}
+		text, _ := util.CleanHTML(s.Content)
+		text = strings.Join(strings.Fields(text), " ")
+		if s.SpoilerText != "" {
+			text = fmt.Sprintf("CW: %s", s.SpoilerText)
+		}
+		date := s.CreatedAt.Local().Format("2006-01-02 15:04")
+		r.toots.AddItem(tview.Escape(fmt.Sprintf("%s %s %s", check, date, text)), "", 0, nil)
+	}
+	if ti < r.toots.GetItemCount() {
+		r.toots.SetCurrentItem(ti)
+	}
+}
+
+func (r *ReportView) HasRulesFocus() bool {
+	return r.focus == reportRulesFocus
+}
+
+func (r *ReportView) HasTootsFocus() bool {
+	return r.focus == reportTootsFocus
+}
+
+func (r *ReportView) setControls(keys []Control) {
+	r.controls.Clear()
+	for i, item := range keys {
+		if i < len(keys)-1 {
+			r.controls.AddItem(NewControlButton(r.tutView, item), item.Len+1, 0, false)
+		} else {
+			r.controls.AddItem(NewControlButton(r.tutView, item), item.Len, 0, false)
+		}
+	}
+}
+
+func (r *ReportView) setListFocus(l *tview.List, focus bool) {
+	cnf := r.tutView.tut.Config
+	if focus {
+		l.SetSelectedBackgroundColor(cnf.Style.ListSelectedBackground)
+		l.SetSelectedTextColor(cnf.Style.ListSelectedText)
+	} else {
+		l.SetSelectedBackgroundColor(cnf.Style.Background)
+		l.SetSelectedTextColor(cnf.Style.Text)
+	}
+}
+
+func (r *ReportView) MainFocus() {
+	r.focus = reportMainFocus
+	cnf := r.tutView.tut.Config
+	var items []Control
+	items = append(items, NewControl(cnf, cnf.Input.ReportCategory, true))
+	if r.report.category == "violation" {
+		items = append(items, NewControl(cnf, cnf.Input.ReportRules, true))
+	}
+	items = append(items, NewControl(cnf, cnf.Input.ReportToots, true))
+	items = append(items, NewControl(cnf, cnf.Input.ReportComment, true))
+	if r.report.account != nil && r.isRemote() {
+		items = append(items, NewControl(cnf, cnf.Input.ReportForward, !r.report.forward))
+	}
+	items = append(items, NewControl(cnf, cnf.Input.ReportSend, true))
+	r.setControls(items)
+	r.setListFocus(r.rules, false)
+	r.setListFocus(r.toots, false)
+}
+
+func (r *ReportView) listFocus(focus reportFocusAt) {
+	r.focus = focus
+	cnf := r.tutView.tut.Config
+	var items []Control
+	items = append(items, NewControl(cnf, cnf.Input.ReportToggle, true))
+	items = append(items, NewControl(cnf, cnf.Input.GlobalBack, true))
+	r.setControls(items)
+	r.setListFocus(r.rules, focus == reportRulesFocus)
+	r.setListFocus(r.toots, focus == reportTootsFocus)
+}
+
+func (r *ReportView) RulesFocus() {
+	if r.report.category != "violation" {
+		return
+	}
+	r.listFocus(reportRulesFocus)
+}
+
+func (r *ReportView) TootsFocus() {
+	r.listFocus(reportTootsFocus)
+}
+
+func (r *ReportView) focusedList() *tview.List {
+	if r.focus == reportRulesFocus {
+		return r.rules
+	}
+	return r.toots
+}
+
+func (r *ReportView) Prev() {
+	l := r.focusedList()
+	index := l.GetCurrentItem()
+	if index-1 >= 0 {
+		l.SetCurrentItem(index - 1)
+	}
+}
+
+func (r *ReportView) Next() {
+	l := r.focusedList()
+	index := l.GetCurrentItem()
+	if index+1 < l.GetItemCount() {
+		l.SetCurrentItem(index + 1)
+	}
+}
+
+func (r *ReportView) Toggle() {
+	l := r.focusedList()
+	if l.GetItemCount() == 0 {
+		return
+	}
+	index := l.GetCurrentItem()
+	if r.focus == reportRulesFocus {
+		if index < 0 || index >= len(r.allRules) {
+			return
+		}
+		id := r.allRules[index].ID
+		if !r.hasRule(id) {
+			r.report.rules = append(r.report.rules, id)
+		} else {
+			var rules []string
+			for _, rule := range r.report.rules {
+				if rule != id {
+					rules = append(rules, rule)
+				}
+			}
+			r.report.rules = rules
+		}
+	} else {
+		if index < 0 || index >= len(r.statuses) {
+			return
+		}
+		id := r.statuses[index].ID
+		if !r.hasToot(id) {
+			r.report.toots = append(r.report.toots, id)
+		} else {
+			var toots []mastodon.ID
+			for _, toot := range r.report.toots {
+				if toot != id {
+					toots = append(toots, toot)
+				}
+			}
+			r.report.toots = toots
+		}
+	}
+	r.update()
+}
+
+func (r *ReportView) ToggleForward() {
+	if !r.isRemote() {
+		return
+	}
+	r.report.forward = !r.report.forward
+	r.update()
+	r.MainFocus()
+}
+
+func (r *ReportView) EditComment() {
+	comment := r.report.comment
+	if r.tutView.tut.Config.General.UseInternalEditor {
+		r.tutView.EditorView.Init(comment, 1000, true, func(input string) {
+			r.editComment(input, nil)
+		})
+	} else {
+		text, err := OpenEditorLengthLimit(r.tutView, comment, 1000)
+		r.editComment(text, err)
+	}
+}
+
+func (r *ReportView) editComment(text string, err error) {
+	if err != nil {
+		r.tutView.ShowError(
+			fmt.Sprintf("Couldn't edit comment. Error: %v\n", err),
+		)
+		return
+	}
+	r.report.comment = strings.TrimSpace(text)
+	r.update()
+}
+
+func (r *ReportView) categoryInput(event *tcell.EventKey) *tcell.EventKey {
+	if r.tutView.tut.Config.Input.GlobalDown.Match(event.Key(), event.Rune()) {
+		return tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	}
+	if r.tutView.tut.Config.Input.GlobalUp.Match(event.Key(), event.Rune()) {
+		return tcell.NewEventKey(tcell.KeyUp, 0, tcell.ModNone)
+	}
+	if r.tutView.tut.Config.Input.GlobalExit.Match(event.Key(), event.Rune()) ||
+		r.tutView.tut.Config.Input.GlobalBack.Match(event.Key(), event.Rune()) {
+		r.exitCategory()
+		return nil
+	}
+	return event
+}
+
+func (r *ReportView) exitCategory() {
+	r.tutView.tut.App.SetInputCapture(r.tutView.Input)
+	r.tutView.tut.App.SetFocus(r.tutView.View)
+}
+
+func (r *ReportView) categorySelected(s string, index int) {
+	_, r.report.category = r.category.GetCurrentOption()
+	r.update()
+	r.MainFocus()
+	r.exitCategory()
+}
+
+func (r *ReportView) FocusCategory() {
+	r.tutView.tut.App.SetInputCapture(r.categoryInput)
+	r.tutView.tut.App.SetFocus(r.category)
+	ev := tcell.NewEventKey(tcell.KeyDown, 0, tcell.ModNone)
+	r.tutView.tut.App.QueueEvent(ev)
+}
+
+func (r *ReportView) Send() {
+	rs := r.report
+	if rs.category == "violation" && len(rs.rules) == 0 {
+		r.tutView.ShowError("Select at least one rule that has been broken.")
+		return
+	}
+	forward := rs.forward && r.isRemote()
+	err := r.tutView.tut.Client.Report(&api.ReportParams{
+		Account:   rs.account,
+		StatusIDs: rs.toots,
+		Comment:   rs.comment,
+		Forward:   forward,
+		Category:  rs.category,
+		RuleIDs:   rs.rules,
+	})
+	if err != nil {
+		r.tutView.ShowError(
+			fmt.Sprintf("Couldn't send report. Error: %v\n", err),
+		)
+		return
+	}
+	r.tutView.FocusMainNoHistory()
+	r.tutView.Shared.Bottom.Cmd.ShowMsg(fmt.Sprintf("Your report of @%s has been sent", rs.account.Acct))
+}
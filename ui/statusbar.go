@@ -33,6 +33,8 @@ const (
 	VoteMode
 	PollMode
 	PreferenceMode
+	FilterMode
+	ReportMode
 )
 
 func (sb *StatusBar) SetMode(m ViewMode) {
@@ -67,5 +69,9 @@ func (sb *StatusBar) SetMode(m ViewMode) {
 		sb.View.SetText("-- CREATE POLL --")
 	case PreferenceMode:
 		sb.View.SetText("-- PREFERENCES --")
+	case FilterMode:
+		sb.View.SetText("-- FILTER --")
+	case ReportMode:
+		sb.View.SetText("-- REPORT --")
 	}
 }
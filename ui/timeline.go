@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/RasmusLindroth/tut/api"
 	"github.com/RasmusLindroth/tut/config"
+	"github.com/rivo/tview"
 )
 
 type FeedHolder struct {
 	Feeds     []*Feed
 	FeedIndex int
+	header    *tview.TextView
 }
 
 type Timeline struct {
@@ -36,6 +39,24 @@ func (fh *FeedHolder) GetTitle() string {
 	return ""
 }
 
+// Unseen returns the number of new items in the feeds of the pane that you
+// haven't scrolled past yet.
+func (fh *FeedHolder) Unseen() int {
+	count := 0
+	for _, f := range fh.Feeds {
+		count += f.Data.Unseen()
+	}
+	return count
+}
+
+func (fh *FeedHolder) headerText() string {
+	title := fh.GetTitle()
+	if unseen := fh.Unseen(); unseen > 0 {
+		return fmt.Sprintf("%s (%d new)", title, unseen)
+	}
+	return title
+}
+
 func CreateFeed(tv *TutView, f *config.Timeline) *Feed {
 	var nf *Feed
 	switch f.FeedType {
@@ -57,10 +78,18 @@ func CreateFeed(tv *TutView, f *config.Timeline) *Feed {
 		nf = NewNotificationFeed(tv, f)
 	case config.Mentions:
 		nf = NewNotificatioMentionsFeed(tv, f)
+	case config.Unified:
+		nf = NewUnifiedFeed(tv, f)
 	case config.Lists:
 		nf = NewListsFeed(tv, f)
 	case config.Tag:
 		nf = NewTagFeed(tv, f)
+	case config.TrendingStatuses:
+		nf = NewTrendingStatusesFeed(tv, f)
+	case config.TrendingTags:
+		nf = NewTrendingTagsFeed(tv, f)
+	case config.TrendingLinks:
+		nf = NewTrendingLinksFeed(tv, f)
 	default:
 		fmt.Println("Invalid feed")
 		tv.CleanExit(1)
@@ -93,63 +122,90 @@ func NewTimeline(tv *TutView, update chan bool) *Timeline {
 	return tl
 }
 
+// timelineName is the name a feed gets when DynamicTimelineName is set.
+func timelineName(ft config.FeedType, name string) string {
+	switch ft {
+	case config.Favorited:
+		return "Favorited"
+	case config.Notifications:
+		return "Notifications"
+	case config.Mentions:
+		return "Mentions"
+	case config.Tag:
+		parts := strings.Split(name, " ")
+		for i, p := range parts {
+			parts[i] = fmt.Sprintf("#%s", p)
+		}
+		return strings.Join(parts, " ")
+	case config.Thread:
+		return "Thread"
+	case config.History:
+		return "History"
+	case config.TimelineFederated:
+		return "Federated"
+	case config.TimelineHome:
+		return "Home"
+	case config.TimelineHomeSpecial:
+		return "Special"
+	case config.TimelineLocal:
+		return "Local"
+	case config.Saved:
+		return "Bookmarked"
+	case config.User:
+		return fmt.Sprintf("@%s", name)
+	case config.UserList:
+		return fmt.Sprintf("Search %s", name)
+	case config.Search:
+		return fmt.Sprintf("Search %s", name)
+	case config.Scheduled:
+		return "Scheduled"
+	case config.Drafts:
+		return "Drafts"
+	case config.Filters:
+		return "Filters"
+	case config.FiltersStatus:
+		return "Add toot to filter"
+	case config.DomainBlocks:
+		return "Domain blocks"
+	case config.TrendingStatuses:
+		return "Trending toots"
+	case config.TrendingTags:
+		return "Trending tags"
+	case config.TrendingLinks:
+		return "Trending links"
+	case config.Link:
+		return "Link"
+	case config.Unified:
+		return fmt.Sprintf("Unified %s", name)
+	case config.Conversations:
+		return "Direct"
+	case config.Lists:
+		return "Lists"
+	case config.List:
+		return fmt.Sprintf("List %s", name)
+	case config.Boosts:
+		return "Boosts"
+	case config.Favorites:
+		return "Favorites"
+	case config.Followers:
+		return "Followers"
+	case config.Following:
+		return "Following"
+	case config.FollowRequests:
+		return "Follow requests"
+	case config.Blocking:
+		return "Blocking"
+	case config.ListUsersAdd:
+		return fmt.Sprintf("Add users to %s", name)
+	case config.ListUsersIn:
+		return fmt.Sprintf("Delete users from %s", name)
+	}
+	return ""
+}
+
 func (tl *Timeline) AddFeed(f *Feed, newPane bool) {
 	if f.Timeline.Name == "" && tl.tutView.tut.Config.General.DynamicTimelineName {
-		name := f.Data.Name()
-		switch f.Timeline.FeedType {
-		case config.Favorited:
-			f.Timeline.Name = "Favorited"
-		case config.Notifications:
-			f.Timeline.Name = "Notifications"
-		case config.Mentions:
-			f.Timeline.Name = "Mentions"
-		case config.Tag:
-			parts := strings.Split(name, " ")
-			for i, p := range parts {
-				parts[i] = fmt.Sprintf("#%s", p)
-			}
-			f.Timeline.Name = strings.Join(parts, " ")
-		case config.Thread:
-			f.Timeline.Name = "Thread"
-		case config.History:
-			f.Timeline.Name = "History"
-		case config.TimelineFederated:
-			f.Timeline.Name = "Federated"
-		case config.TimelineHome:
-			f.Timeline.Name = "Home"
-		case config.TimelineHomeSpecial:
-			f.Timeline.Name = "Special"
-		case config.TimelineLocal:
-			f.Timeline.Name = "Local"
-		case config.Saved:
-			f.Timeline.Name = "Bookmarked"
-		case config.User:
-			f.Timeline.Name = fmt.Sprintf("@%s", name)
-		case config.UserList:
-			f.Timeline.Name = fmt.Sprintf("Search %s", name)
-		case config.Conversations:
-			f.Timeline.Name = "Direct"
-		case config.Lists:
-			f.Timeline.Name = "Lists"
-		case config.List:
-			f.Timeline.Name = fmt.Sprintf("List %s", name)
-		case config.Boosts:
-			f.Timeline.Name = "Boosts"
-		case config.Favorites:
-			f.Timeline.Name = "Favorites"
-		case config.Followers:
-			f.Timeline.Name = "Followers"
-		case config.Following:
-			f.Timeline.Name = "Following"
-		case config.FollowRequests:
-			f.Timeline.Name = "Follow requests"
-		case config.Blocking:
-			f.Timeline.Name = "Blocking"
-		case config.ListUsersAdd:
-			f.Timeline.Name = fmt.Sprintf("Add users to %s", name)
-		case config.ListUsersIn:
-			f.Timeline.Name = fmt.Sprintf("Delete users from %s", name)
-		}
+		f.Timeline.Name = timelineName(f.Timeline.FeedType, f.Data.Name())
 	}
 
 	if newPane {
@@ -188,6 +244,48 @@ func (tl *Timeline) RemoveCurrent(quit bool) bool {
 	return false
 }
 
+// RemoveFeeds closes and removes all feeds that match. Panes where all feeds
+// match are closed, if it's the last pane it shows your home timeline instead.
+func (tl *Timeline) RemoveFeeds(match func(*Feed) bool) {
+	var panes []*FeedHolder
+	focus := tl.FeedFocusIndex
+	for p, fh := range tl.Feeds {
+		var feeds []*Feed
+		index := fh.FeedIndex
+		for i, f := range fh.Feeds {
+			if !match(f) {
+				feeds = append(feeds, f)
+				continue
+			}
+			f.Data.Close()
+			if i <= fh.FeedIndex && index > 0 {
+				index--
+			}
+		}
+		if len(feeds) == 0 {
+			if p < tl.FeedFocusIndex {
+				focus--
+			}
+			continue
+		}
+		fh.Feeds = feeds
+		fh.FeedIndex = index
+		panes = append(panes, fh)
+	}
+	if len(panes) == 0 {
+		home := CreateFeed(tl.tutView, config.NewTimeline(config.Timeline{
+			FeedType: config.TimelineHome,
+			Name:     "Home",
+		}))
+		panes = append(panes, &FeedHolder{Feeds: []*Feed{home}})
+	}
+	tl.Feeds = panes
+	if focus >= len(panes) {
+		focus = len(panes) - 1
+	}
+	tl.tutView.FocusFeed(focus, nil)
+}
+
 func (tl *Timeline) MoveCurrentPaneLeft() {
 	length := len(tl.Feeds)
 	if length < 2 {
@@ -295,6 +393,12 @@ func (tl *Timeline) DrawContent() {
 	fh := tl.Feeds[tl.FeedFocusIndex]
 	f := fh.Feeds[fh.FeedIndex]
 	f.DrawContent()
+	f.Data.MarkSeen(f.List.GetCurrentIndex())
+	if item, err := f.Data.Item(f.List.GetCurrentIndex()); err == nil {
+		f.Data.MarkRead(item)
+		tl.tutView.Shared.Top.SetText(tl.GetTitle())
+	}
+	TutViews.UpdateUnseen()
 }
 
 func (fh *FeedHolder) GetFeedList() *FeedList {
@@ -345,6 +449,28 @@ func (tl *Timeline) GetTitle() string {
 		ct = fmt.Sprintf("user %s", name)
 	case config.UserList:
 		ct = fmt.Sprintf("user search %s", name)
+	case config.Search:
+		ct = fmt.Sprintf("search %s", name)
+	case config.Scheduled:
+		ct = "scheduled"
+	case config.Drafts:
+		ct = "drafts"
+	case config.Filters:
+		ct = "filters"
+	case config.FiltersStatus:
+		ct = "add toot to filter"
+	case config.DomainBlocks:
+		ct = "domain blocks"
+	case config.TrendingStatuses:
+		ct = "trending toots"
+	case config.TrendingTags:
+		ct = "trending tags"
+	case config.TrendingLinks:
+		ct = "trending links"
+	case config.Link:
+		ct = fmt.Sprintf("toots sharing %s", name)
+	case config.Unified:
+		ct = fmt.Sprintf("unified %s", name)
 	case config.Conversations:
 		ct = "direct"
 	case config.Lists:
@@ -368,6 +494,22 @@ func (tl *Timeline) GetTitle() string {
 	case config.ListUsersIn:
 		ct = fmt.Sprintf("Delete users from %s", name)
 	}
+	if unread, more, ok := f.Data.Unread(); ok && unread > 0 {
+		if more {
+			ct = fmt.Sprintf("%s, %d+ unread", ct, unread)
+		} else {
+			ct = fmt.Sprintf("%s, %d unread", ct, unread)
+		}
+	}
+	if f.Data.Polling() {
+		return fmt.Sprintf("%s (%d/%d) [poll]", ct, index+1, total)
+	}
+	if state, ok := f.Data.StreamState(); ok {
+		if state != api.StreamConnected {
+			return fmt.Sprintf("%s (%d/%d) [stream, %s]", ct, index+1, total, state)
+		}
+		return fmt.Sprintf("%s (%d/%d) [stream]", ct, index+1, total)
+	}
 	return fmt.Sprintf("%s (%d/%d)", ct, index+1, total)
 }
 
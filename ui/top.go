@@ -11,6 +11,7 @@ import (
 type Top struct {
 	TutView *TutView
 	View    *tview.TextView
+	text    string
 }
 
 func NewTop(tv *TutView) *Top {
@@ -47,8 +48,20 @@ func (t *Top) SetText(s string) {
 }
 
 func (t *Top) setText(s string) {
+	t.text = s
 	t.View.SetText(s)
 	if t.TutView.tut.Config.General.TerminalTitle > 0 && t.TutView.tut.Config.General.TerminalTitle != 3 {
+		if unseen := t.TutView.Unseen(); unseen > 0 && t.TutView.tut.Config.General.TerminalTitleUnseen {
+			s = fmt.Sprintf("(%d) %s", unseen, s)
+		}
 		util.SetTerminalTitle(s)
 	}
 }
+
+// Refresh sets the text again, so the terminal title shows the number of
+// unseen items.
+func (t *Top) Refresh() {
+	if t.text != "" {
+		t.setText(t.text)
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
 	"github.com/RasmusLindroth/tut/auth"
 	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/feed"
 	"github.com/rivo/tview"
 )
 
@@ -68,14 +69,21 @@ type TutView struct {
 	VoteView       *VoteView
 	PollView       *PollView
 	PreferenceView *PreferenceView
+	FilterView     *FilterView
+	ReportView     *ReportView
 	HelpView       *HelpView
 	EditorView     *EditorView
 	ModalView      *ModalView
 
 	FileList []string
+
+	// pendingLogin waits for the code when you log in to a new account
+	// without a browser on this computer.
+	pendingLogin *auth.Login
 }
 
 func (tv *TutView) CleanExit(code int) {
+	feed.SaveMarkers()
 	tv.ClearTemp()
 	os.Exit(code)
 }
@@ -115,11 +123,10 @@ func (l *Leader) Content() string {
 	return l.content
 }
 
-func NewTutView(selectedUser string) {
+func newTutView() *TutView {
 	if TutViews == nil {
 		TutViews = &TutViewsHolder{}
 	}
-	accs := Accounts
 	tv := &TutView{
 		tut: &Tut{
 			Client: &api.AccountClient{},
@@ -131,28 +138,18 @@ func NewTutView(selectedUser string) {
 	}
 	tv.Leader = NewLeader(tv)
 	tv.Shared = NewShared(tv)
+	return tv
+}
+
+func NewTutView(selectedUser string) {
+	accs := Accounts
+	tv := newTutView()
 	if selectedUser != "" {
-		useHost := false
-		found := false
-		if strings.Contains(selectedUser, "@") {
-			useHost = true
-		}
-		for _, acc := range accs.Accounts {
-			accName := acc.Name
-			if useHost {
-				host := strings.TrimPrefix(acc.Server, "https://")
-				host = strings.TrimPrefix(host, "http://")
-				accName += "@" + host
-			}
-			if accName == selectedUser {
-				tv.loggedIn(acc)
-				found = true
-				break
-			}
-		}
+		acc, found := findAccount(accs, selectedUser)
 		if !found {
 			log.Fatalf("Couldn't find a user named %s. Try again", selectedUser)
 		}
+		tv.loggedIn(acc)
 	} else if len(accs.Accounts) > 1 {
 		tv.LoginView = NewLoginView(tv, accs)
 		tv.View.AddPage("login", tv.LoginView.View, true, true)
@@ -164,6 +161,24 @@ func NewTutView(selectedUser string) {
 	TutViews.SetFocusedTutView(len(TutViews.Views) - 1)
 }
 
+// newTutViewAccount opens a view for an account you just added.
+func newTutViewAccount(acc auth.Account) {
+	tv := newTutView()
+	tv.loggedIn(acc)
+	TutViews.Views = append(TutViews.Views, tv)
+	TutViews.SetFocusedTutView(len(TutViews.Views) - 1)
+}
+
+// findAccount returns the account named name. If two accounts have the same
+// name the host can be included, e.g. tut@fosstodon.org.
+func findAccount(accs *auth.AccountData, name string) (auth.Account, bool) {
+	i, ok := accs.Find(name)
+	if !ok {
+		return auth.Account{}, false
+	}
+	return accs.Accounts[i], true
+}
+
 func (tvh *TutViewsHolder) SetFocusedTutView(index int) {
 	if index < 0 && index >= len(tvh.Views) {
 		return
@@ -202,6 +217,106 @@ func (tvh *TutViewsHolder) Prev() {
 	tvh.SetFocusedTutView(prev)
 }
 
+// accountName returns the name of the account with the host, e.g.
+// tut@fosstodon.org.
+func (tv *TutView) accountName() string {
+	return clientName(tv.tut.Client)
+}
+
+// clientName is the name of the account with the instance, as two accounts
+// on different instances can have the same name.
+func clientName(ac *api.AccountClient) string {
+	acct := ac.Me
+	u, err := url.Parse(acct.URL)
+	if err != nil {
+		return acct.Acct
+	}
+	return fmt.Sprintf("%s@%s", acct.Acct, u.Host)
+}
+
+// otherAccounts returns the other accounts you are logged in to.
+func (tv *TutView) otherAccounts() []*TutView {
+	var views []*TutView
+	for _, t := range TutViews.Views {
+		if t == tv || t.tut.Client == nil || t.tut.Client.Me == nil {
+			continue
+		}
+		views = append(views, t)
+	}
+	return views
+}
+
+// itemOwner returns the view of the account that received the selected item.
+// It's only another account in a unified timeline.
+func (tv *TutView) itemOwner() *TutView {
+	item, err := tv.GetCurrentItem()
+	if err != nil {
+		return tv
+	}
+	ac := tv.GetCurrentFeed().Data.ItemClient(item)
+	for _, t := range TutViews.Views {
+		if t.tut.Client == ac {
+			return t
+		}
+	}
+	return tv
+}
+
+// Focus shows the view of tv if it isn't shown already.
+func (tvh *TutViewsHolder) Focus(tv *TutView) {
+	for i, t := range tvh.Views {
+		if t == tv {
+			if i != tvh.Current {
+				tvh.SetFocusedTutView(i)
+			}
+			return
+		}
+	}
+}
+
+// joinUnified adds the account to the unified timelines of the accounts that
+// are already logged in.
+func (tv *TutView) joinUnified() {
+	for _, t := range tv.otherAccounts() {
+		if t.Timeline == nil {
+			continue
+		}
+		for _, fh := range t.Timeline.Feeds {
+			for _, f := range fh.Feeds {
+				if f.Data.Type() == config.Unified {
+					f.Data.AddAccount(tv.tut.Client)
+				}
+			}
+		}
+	}
+}
+
+// Unseen returns the number of new items in all panes that you haven't
+// scrolled past yet.
+func (tv *TutView) Unseen() int {
+	if tv.Timeline == nil {
+		return 0
+	}
+	count := 0
+	for _, fh := range tv.Timeline.Feeds {
+		count += fh.Unseen()
+	}
+	return count
+}
+
+// UpdateUnseen shows the new number of unseen items in the view that is shown.
+func (tvh *TutViewsHolder) UpdateUnseen() {
+	if tvh.Current >= len(tvh.Views) {
+		return
+	}
+	curr := tvh.Views[tvh.Current]
+	if curr.MainView == nil || curr.Timeline == nil {
+		return
+	}
+	curr.MainView.updateUnseen(curr)
+	curr.Shared.Top.Refresh()
+}
+
 func DoneAdding() {
 	if len(TutViews.Views) > 0 {
 		TutViews.SetFocusedTutView(0)
@@ -254,6 +369,8 @@ func (tv *TutView) loggedIn(acc auth.Account) {
 	tv.VoteView = NewVoteView(tv)
 	tv.PollView = NewPollView(tv)
 	tv.PreferenceView = NewPreferenceView(tv)
+	tv.FilterView = NewFilterView(tv)
+	tv.ReportView = NewReportView(tv)
 	tv.HelpView = NewHelpView(tv)
 	tv.EditorView = NewEditorView(tv)
 	tv.ModalView = NewModalView(tv)
@@ -266,8 +383,11 @@ func (tv *TutView) loggedIn(acc auth.Account) {
 	tv.View.AddPage("editor", tv.EditorView.View, true, false)
 	tv.View.AddPage("poll", tv.PollView.View, true, false)
 	tv.View.AddPage("preference", tv.PreferenceView.View, true, false)
+	tv.View.AddPage("filter", tv.FilterView.View, true, false)
+	tv.View.AddPage("report", tv.ReportView.View, true, false)
 	tv.View.AddPage("modal", tv.ModalView.View, true, false)
 	tv.SetPage(MainFocus)
+	tv.joinUnified()
 }
 
 func (tv *TutView) FocusFeed(index int, ct *config.Timeline) {
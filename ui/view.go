@@ -1,10 +1,16 @@
 package ui
 
 import (
+	"fmt"
 	"log"
+	"strings"
 
 	"github.com/RasmusLindroth/go-mastodon"
 	"github.com/RasmusLindroth/tut/api"
+	"github.com/RasmusLindroth/tut/config"
+	"github.com/RasmusLindroth/tut/feed"
+	"github.com/RasmusLindroth/tut/util"
+	"golang.org/x/exp/slices"
 )
 
 type PageFocusAt uint
@@ -18,12 +24,15 @@ const (
 	ComposeFocus
 	MediaFocus
 	MediaAddFocus
+	CrossPostFocus
 	CmdFocus
 	VoteFocus
 	HelpFocus
 	EditorFocus
 	PollFocus
 	PreferenceFocus
+	FilterFocus
+	ReportFocus
 )
 
 func (tv *TutView) GetCurrentFeed() *Feed {
@@ -33,22 +42,22 @@ func (tv *TutView) GetCurrentFeed() *Feed {
 
 func (tv *TutView) GetCurrentItem() (api.Item, error) {
 	f := tv.GetCurrentFeed()
-	return f.Data.Item(f.List.Text.GetCurrentItem())
+	return f.Data.Item(f.List.GetCurrentIndex())
 }
 
 func (tv *TutView) RedrawContent() {
 	f := tv.GetCurrentFeed()
-	item, err := f.Data.Item(f.List.Text.GetCurrentItem())
+	item, err := f.Data.Item(f.List.GetCurrentIndex())
 	if err != nil {
 		f.Content.Main.SetText("")
 		f.Content.Controls.Clear()
 		return
 	}
-	DrawItem(tv, item, f.Content.Main, f.Content.Controls, f.Data.Type())
+	DrawItem(tv, item, f.Content.Main, f.Content.Controls, f.Data.FilterType())
 }
 func (tv *TutView) RedrawPoll(poll *mastodon.Poll) {
 	f := tv.GetCurrentFeed()
-	item, err := f.Data.Item(f.List.Text.GetCurrentItem())
+	item, err := f.Data.Item(f.List.GetCurrentIndex())
 	if err != nil {
 		return
 	}
@@ -62,15 +71,15 @@ func (tv *TutView) RedrawPoll(poll *mastodon.Poll) {
 	} else {
 		so.Poll = poll
 	}
-	DrawItem(tv, item, f.Content.Main, f.Content.Controls, f.Data.Type())
+	DrawItem(tv, item, f.Content.Main, f.Content.Controls, f.Data.FilterType())
 }
 func (tv *TutView) RedrawControls() {
 	f := tv.GetCurrentFeed()
-	item, err := f.Data.Item(f.List.Text.GetCurrentItem())
+	item, err := f.Data.Item(f.List.GetCurrentIndex())
 	if err != nil {
 		return
 	}
-	DrawItemControls(tv, item, f.Content.Controls, f.Data.Type())
+	DrawItemControls(tv, item, f.Content.Controls, f.Data.FilterType())
 }
 
 func (tv *TutView) SetPage(f PageFocusAt) {
@@ -122,6 +131,10 @@ func (tv *TutView) SetPage(f PageFocusAt) {
 	case MediaAddFocus:
 		tv.PageFocus = MediaAddFocus
 		tv.tut.App.SetFocus(tv.ComposeView.input.View)
+	case CrossPostFocus:
+		tv.PageFocus = CrossPostFocus
+		tv.ComposeView.SetControls(ComposeCrossPost)
+		tv.tut.App.SetFocus(tv.View)
 	case CmdFocus:
 		tv.PageFocus = CmdFocus
 		tv.tut.App.SetFocus(tv.Shared.Bottom.Cmd.View)
@@ -160,6 +173,18 @@ func (tv *TutView) SetPage(f PageFocusAt) {
 		tv.tut.App.SetFocus(tv.View)
 		tv.Shared.Bottom.StatusBar.SetMode(PreferenceMode)
 		tv.Shared.Top.SetText("preferences")
+	case FilterFocus:
+		tv.PageFocus = FilterFocus
+		tv.View.SwitchToPage("filter")
+		tv.tut.App.SetFocus(tv.View)
+		tv.Shared.Bottom.StatusBar.SetMode(FilterMode)
+		tv.Shared.Top.SetText("edit filter")
+	case ReportFocus:
+		tv.PageFocus = ReportFocus
+		tv.View.SwitchToPage("report")
+		tv.tut.App.SetFocus(tv.View)
+		tv.Shared.Bottom.StatusBar.SetMode(ReportMode)
+		tv.Shared.Top.SetText("report")
 	}
 	tv.ShouldSync()
 }
@@ -176,11 +201,254 @@ func (tv *TutView) PrevFocus() {
 
 func (tv *TutView) InitPost(status *mastodon.Status, original *mastodon.Status) {
 	err := tv.ComposeView.SetStatus(status, original)
+	if err != nil {
+		return
+	}
+	tv.SetPage(ComposeFocus)
+	d := tv.ComposeView.UnsentDraft()
+	if d == nil {
+		return
+	}
+	tv.ModalView.RunDecide(
+		fmt.Sprintf("You have an unsent draft from %s. Do you want to restore it?", d.UpdatedAt.Local().Format("2006-01-02 15:04")),
+		func() {
+			tv.tut.App.QueueUpdateDraw(func() {
+				tv.ComposeView.SetDraft(d)
+			})
+		}, func() {})
+}
+
+func (tv *TutView) InitDraftPost(d *util.Draft) {
+	err := tv.ComposeView.SetDraft(d)
+	if err == nil {
+		tv.SetPage(ComposeFocus)
+	}
+}
+
+func (tv *TutView) InitScheduledPost(s *api.ScheduledStatus) {
+	err := tv.ComposeView.SetScheduled(s)
 	if err == nil {
 		tv.SetPage(ComposeFocus)
 	}
 }
 
+// RemoveScheduled removes a scheduled toot that has been posted or
+// canceled from all scheduled feeds.
+func (tv *TutView) RemoveScheduled(s *api.ScheduledStatus) {
+	tv.forEachFeed(func(f *Feed) {
+		deleteItems(f, api.ScheduledType, func(raw interface{}) bool {
+			return raw.(*api.ScheduledStatus).ID == s.ID
+		})
+	}, config.Scheduled)
+}
+
+func (tv *TutView) InitReport(account *mastodon.Account, status *mastodon.Status) {
+	err := tv.ReportView.SetReport(account, status)
+	if err != nil {
+		tv.ShowError(
+			fmt.Sprintf("Couldn't start the report. Error: %v\n", err),
+		)
+		return
+	}
+	tv.SetPage(ReportFocus)
+}
+
+func (tv *TutView) InitFilter(f *api.Filter) {
+	tv.FilterView.SetFilter(f)
+	tv.SetPage(FilterFocus)
+}
+
+// UpdateFilter updates a filter in all filter feeds. New filters are added
+// to the top of the feeds.
+func (tv *TutView) UpdateFilter(filter *api.Filter, created bool) {
+	tv.forEachFeed(func(f *Feed) {
+		if created {
+			if f.Data.Type() == config.Filters {
+				nf := *filter
+				f.Data.Prepend(api.NewFilterItem(&api.FilterData{Filter: &nf}))
+			}
+			return
+		}
+		for _, item := range f.Data.List() {
+			if item.Type() != api.FilterType {
+				continue
+			}
+			fd := item.Raw().(*api.FilterData)
+			if fd.Filter.ID == filter.ID {
+				*fd.Filter = *filter
+			}
+		}
+		f.Data.Updated(feed.DesktopNotificationHolder{Type: feed.DesktopNotificationNone})
+	}, config.Filters, config.FiltersStatus)
+}
+
+// RemoveFilter removes a deleted filter from all filter feeds.
+func (tv *TutView) RemoveFilter(filter *api.Filter) {
+	tv.forEachFeed(func(f *Feed) {
+		deleteItems(f, api.FilterType, func(raw interface{}) bool {
+			return raw.(*api.FilterData).Filter.ID == filter.ID
+		})
+	}, config.Filters, config.FiltersStatus)
+}
+
+// AddDomainBlock adds a blocked domain to the top of all domain block feeds
+// that don't have it already.
+func (tv *TutView) AddDomainBlock(domain string) {
+	tv.forEachFeed(func(f *Feed) {
+		for _, item := range f.Data.List() {
+			if item.Type() == api.DomainType && item.Raw().(string) == domain {
+				return
+			}
+		}
+		f.Data.Prepend(api.NewDomainItem(domain))
+	}, config.DomainBlocks)
+}
+
+// RemoveDomainBlock removes an unblocked domain from all domain block feeds.
+func (tv *TutView) RemoveDomainBlock(domain string) {
+	tv.forEachFeed(func(f *Feed) {
+		deleteItems(f, api.DomainType, func(raw interface{}) bool {
+			return raw.(string) == domain
+		})
+	}, config.DomainBlocks)
+}
+
+// EditListTitle asks for the title of a new list, or a new title for list
+// when it isn't nil.
+func (tv *TutView) EditListTitle(list *api.List) {
+	title := ""
+	if list != nil {
+		title = list.Title
+	}
+	if tv.tut.Config.General.UseInternalEditor {
+		tv.EditorView.Init(title, 100, true, func(input string) {
+			tv.editListTitle(list, input, nil)
+		})
+	} else {
+		text, err := OpenEditorLengthLimit(tv, title, 100)
+		tv.editListTitle(list, text, err)
+	}
+}
+
+func (tv *TutView) editListTitle(list *api.List, text string, err error) {
+	if err != nil {
+		tv.ShowError(
+			fmt.Sprintf("Couldn't edit title. Error: %v\n", err),
+		)
+		return
+	}
+	title := strings.TrimSpace(text)
+	if title == "" {
+		return
+	}
+	if list == nil {
+		tv.CreateList(title)
+		return
+	}
+	if title != list.Title {
+		tv.UpdateList(list, title, list.RepliesPolicy, list.Exclusive)
+	}
+}
+
+func (tv *TutView) CreateList(title string) {
+	l, err := tv.tut.Client.CreateList(title)
+	if err != nil {
+		tv.ShowError(
+			fmt.Sprintf("Couldn't create list. Error: %v\n", err),
+		)
+		return
+	}
+	tv.forEachFeed(func(f *Feed) {
+		nl := *l
+		f.Data.Prepend(api.NewListsItem(&nl))
+	}, config.Lists)
+}
+
+// UpdateList saves the settings of list and updates it in all list feeds
+// and in the panes that show it.
+func (tv *TutView) UpdateList(list *api.List, title string, repliesPolicy string, exclusive bool) {
+	l, err := tv.tut.Client.UpdateList(list.ID, title, repliesPolicy, exclusive)
+	if err != nil {
+		tv.ShowError(
+			fmt.Sprintf("Couldn't update list. Error: %v\n", err),
+		)
+		return
+	}
+	oldTitle := list.Title
+	dynamic := tv.tut.Config.General.DynamicTimelineName
+	tv.forEachFeed(func(f *Feed) {
+		if f.Data.Type() == config.Lists {
+			for _, item := range f.Data.List() {
+				if item.Type() != api.ListsType {
+					continue
+				}
+				ld := item.Raw().(*api.List)
+				if ld.ID == l.ID {
+					*ld = *l
+				}
+			}
+			f.Data.Updated(feed.DesktopNotificationHolder{Type: feed.DesktopNotificationNone})
+			return
+		}
+		ld := f.Data.ListData()
+		if ld == nil || ld.ID != l.ID {
+			return
+		}
+		ld.Title = l.Title
+		if dynamic && f.Timeline.Name == timelineName(f.Timeline.FeedType, oldTitle) {
+			f.Timeline.Name = timelineName(f.Timeline.FeedType, l.Title)
+		}
+	})
+	tv.Shared.Top.SetText(tv.Timeline.GetTitle())
+	tv.Timeline.update <- true
+}
+
+// RemoveList removes a deleted list from all list feeds and closes the feeds
+// that show it.
+func (tv *TutView) RemoveList(list *api.List) {
+	id := list.ID
+	tv.forEachFeed(func(f *Feed) {
+		deleteItems(f, api.ListsType, func(raw interface{}) bool {
+			return raw.(*api.List).ID == id
+		})
+	}, config.Lists)
+	tv.Timeline.RemoveFeeds(func(f *Feed) bool {
+		ld := f.Data.ListData()
+		return ld != nil && ld.ID == id
+	})
+}
+
+// RemoveDraft removes a draft that has been posted or deleted from all
+// draft feeds.
+func (tv *TutView) RemoveDraft(d *util.Draft) {
+	tv.forEachFeed(func(f *Feed) {
+		deleteItems(f, api.DraftType, func(raw interface{}) bool {
+			return raw.(*util.Draft).ID == d.ID
+		})
+	}, config.Drafts)
+}
+
+// forEachFeed calls fn for all open feeds, or only for the feeds of one of
+// the types if you pass any.
+func (tv *TutView) forEachFeed(fn func(f *Feed), types ...config.FeedType) {
+	for _, fh := range tv.Timeline.Feeds {
+		for _, f := range fh.Feeds {
+			if len(types) == 0 || slices.Contains(types, f.Data.Type()) {
+				fn(f)
+			}
+		}
+	}
+}
+
+// deleteItems deletes the items of type t in f where match is true.
+func deleteItems(f *Feed, t api.MastodonType, match func(raw interface{}) bool) {
+	for _, item := range f.Data.List() {
+		if item.Type() == t && match(item.Raw()) {
+			f.Data.Delete(item.ID())
+		}
+	}
+}
+
 func (tv *TutView) ShowError(s string) {
 	tv.Shared.Bottom.Cmd.ShowError(s)
 }
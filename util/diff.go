@@ -0,0 +1,177 @@
+package util
+
+import (
+	"strings"
+	"unicode"
+)
+
+type DiffOp uint
+
+const (
+	DiffEqual DiffOp = iota
+	DiffInsert
+	DiffDelete
+)
+
+type DiffPart struct {
+	Op   DiffOp
+	Text string
+}
+
+// diffMaxCells limits the size of the table used to compare two texts. Texts
+// that differ more than that are shown as removed and added in full.
+const diffMaxCells = 1 << 22
+
+// DiffWords compares the words in a and b. The whitespace between the words
+// is kept in the parts.
+func DiffWords(a, b string) []DiffPart {
+	return groupDiff(diffTokens(splitWords(a), splitWords(b)))
+}
+
+// DiffList compares two lists, e.g. the options of a poll. Each part is one
+// item of the lists.
+func DiffList(a, b []string) []DiffPart {
+	return diffTokens(a, b)
+}
+
+func splitWords(s string) []string {
+	var words []string
+	start := 0
+	space := false
+	for i, r := range s {
+		if i == 0 {
+			space = unicode.IsSpace(r)
+			continue
+		}
+		if unicode.IsSpace(r) != space {
+			words = append(words, s[start:i])
+			start = i
+			space = !space
+		}
+	}
+	if start < len(s) {
+		words = append(words, s[start:])
+	}
+	return words
+}
+
+func diffTokens(a, b []string) []DiffPart {
+	var parts []DiffPart
+	pre := 0
+	for pre < len(a) && pre < len(b) && a[pre] == b[pre] {
+		parts = append(parts, DiffPart{Op: DiffEqual, Text: a[pre]})
+		pre++
+	}
+	suf := 0
+	for suf < len(a)-pre && suf < len(b)-pre && a[len(a)-1-suf] == b[len(b)-1-suf] {
+		suf++
+	}
+	ma, mb := a[pre:len(a)-suf], b[pre:len(b)-suf]
+	if (len(ma)+1)*(len(mb)+1) > diffMaxCells {
+		for _, t := range ma {
+			parts = append(parts, DiffPart{Op: DiffDelete, Text: t})
+		}
+		for _, t := range mb {
+			parts = append(parts, DiffPart{Op: DiffInsert, Text: t})
+		}
+	} else {
+		parts = append(parts, lcsDiff(ma, mb)...)
+	}
+	for _, t := range a[len(a)-suf:] {
+		parts = append(parts, DiffPart{Op: DiffEqual, Text: t})
+	}
+	return parts
+}
+
+// lcsDiff finds the longest common subsequence of a and b and returns the
+// tokens that aren't in it as deleted or inserted.
+func lcsDiff(a, b []string) []DiffPart {
+	n, m := len(a), len(b)
+	w := m + 1
+	table := make([]int32, (n+1)*w)
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i*w+j] = table[(i+1)*w+j+1] + 1
+			} else if table[(i+1)*w+j] >= table[i*w+j+1] {
+				table[i*w+j] = table[(i+1)*w+j]
+			} else {
+				table[i*w+j] = table[i*w+j+1]
+			}
+		}
+	}
+	var parts []DiffPart
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			parts = append(parts, DiffPart{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case table[(i+1)*w+j] >= table[i*w+j+1]:
+			parts = append(parts, DiffPart{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			parts = append(parts, DiffPart{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		parts = append(parts, DiffPart{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		parts = append(parts, DiffPart{Op: DiffInsert, Text: b[j]})
+	}
+	return parts
+}
+
+// groupDiff joins the changes next to each other, and the whitespace between
+// them, to one deletion followed by one insertion, so a changed sentence isn't
+// shown word by word.
+func groupDiff(parts []DiffPart) []DiffPart {
+	var out []DiffPart
+	var del, ins string
+	add := func(op DiffOp, text string) {
+		if text == "" {
+			return
+		}
+		if l := len(out) - 1; l >= 0 && out[l].Op == op {
+			out[l].Text += text
+			return
+		}
+		out = append(out, DiffPart{Op: op, Text: text})
+	}
+	flush := func() {
+		switch {
+		case del != "" && ins != "" && strings.TrimSpace(ins) == "":
+			add(DiffDelete, del)
+			add(DiffEqual, ins)
+		case del != "" && ins != "" && strings.TrimSpace(del) == "":
+			add(DiffEqual, del)
+			add(DiffInsert, ins)
+		default:
+			add(DiffDelete, del)
+			add(DiffInsert, ins)
+		}
+		del, ins = "", ""
+	}
+	for i, p := range parts {
+		switch p.Op {
+		case DiffDelete:
+			del += p.Text
+		case DiffInsert:
+			ins += p.Text
+		default:
+			changing := del != "" || ins != ""
+			if changing && strings.TrimSpace(p.Text) == "" && i+1 < len(parts) && parts[i+1].Op != DiffEqual {
+				del += p.Text
+				ins += p.Text
+				continue
+			}
+			flush()
+			add(DiffEqual, p.Text)
+		}
+	}
+	flush()
+	return out
+}
@@ -0,0 +1,125 @@
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/adrg/xdg"
+)
+
+type Draft struct {
+	ID          string       `json:"id"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Text        string       `json:"text"`
+	CWText      string       `json:"cw_text"`
+	Sensitive   bool         `json:"sensitive"`
+	Visibility  string       `json:"visibility"`
+	Language    string       `json:"language"`
+	Media       []DraftMedia `json:"media"`
+	Poll        *DraftPoll   `json:"poll"`
+	InReplyToID mastodon.ID  `json:"in_reply_to_id"`
+}
+
+type DraftMedia struct {
+	Path        string `json:"path"`
+	Description string `json:"description"`
+}
+
+type DraftPoll struct {
+	Options   []string `json:"options"`
+	ExpiresIn int64    `json:"expires_in"`
+	Multiple  bool     `json:"multiple"`
+}
+
+func NewDraftID() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 10)
+}
+
+// GetDraftsDir returns the directory where the drafts for an account are
+// stored, e.g. $XDG_DATA_HOME/tut/drafts/rasmus@mastodon.acc.sunet.se
+func GetDraftsDir(account string) (string, error) {
+	if xdg.DataHome == "" {
+		return "", errors.New("couldn't find the data dir")
+	}
+	account = strings.NewReplacer("/", "_", "\\", "_", ":", "_").Replace(account)
+	return filepath.Join(xdg.DataHome, "tut", "drafts", account), nil
+}
+
+// LoadDrafts returns all drafts for an account, the most recent first.
+func LoadDrafts(account string) ([]*Draft, error) {
+	dir, err := GetDraftsDir(account)
+	if err != nil {
+		return nil, err
+	}
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var drafts []*Draft
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, f.Name()))
+		if err != nil {
+			continue
+		}
+		d := &Draft{}
+		if err := json.Unmarshal(data, d); err != nil || d.ID == "" {
+			continue
+		}
+		drafts = append(drafts, d)
+	}
+	sort.Slice(drafts, func(i, j int) bool {
+		return drafts[i].UpdatedAt.After(drafts[j].UpdatedAt)
+	})
+	return drafts, nil
+}
+
+// SaveDraft writes the draft to disk. It writes to a temporary file first so
+// a crash while saving doesn't leave a broken draft behind.
+func SaveDraft(account string, d *Draft) error {
+	dir, err := GetDraftsDir(account)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	if d.ID == "" {
+		d.ID = NewDraftID()
+	}
+	d.UpdatedAt = time.Now()
+	data, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return err
+	}
+	p := filepath.Join(dir, d.ID+".json")
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, p)
+}
+
+func DeleteDraft(account string, d *Draft) error {
+	dir, err := GetDraftsDir(account)
+	if err != nil {
+		return err
+	}
+	err = os.Remove(filepath.Join(dir, d.ID+".json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
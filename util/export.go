@@ -0,0 +1,234 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/RasmusLindroth/go-mastodon"
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// ExportFormats are the formats toots can be exported to.
+var ExportFormats = []string{"markdown", "html", "json"}
+
+// DownloadFile downloads url to the file dest.
+func DownloadFile(url string, dest string) error {
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("couldn't download %s: %s", url, resp.Status)
+	}
+	f, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, resp.Body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(dest)
+	}
+	return err
+}
+
+// MediaFilename returns the name a media attachment is saved as.
+func MediaFilename(status *mastodon.Status, index int, attachment mastodon.Attachment) string {
+	ext := ""
+	if u, err := url.Parse(attachment.URL); err == nil {
+		ext = path.Ext(u.Path)
+	}
+	return fmt.Sprintf("%s_%d%s", status.ID, index+1, ext)
+}
+
+// ExportStatuses writes statuses to the file at p in one of ExportFormats.
+// The media of the statuses is downloaded to a folder next to the file, it
+// has the same name as the file but ends with _media. Media that can't be
+// downloaded links to the server instead.
+func ExportStatuses(statuses []*mastodon.Status, format string, p string) error {
+	switch format {
+	case "markdown", "md", "html", "json":
+	default:
+		return fmt.Errorf("unknown format %s, use one of %s", format, strings.Join(ExportFormats, ", "))
+	}
+	mediaDir := strings.TrimSuffix(p, filepath.Ext(p)) + "_media"
+	media := make(map[string]string)
+	for _, s := range statuses {
+		s = StatusOrReblog(s)
+		for i, a := range s.MediaAttachments {
+			if a.URL == "" {
+				continue
+			}
+			if err := os.MkdirAll(mediaDir, 0755); err != nil {
+				return err
+			}
+			name := MediaFilename(s, i, a)
+			if err := DownloadFile(a.URL, filepath.Join(mediaDir, name)); err != nil {
+				continue
+			}
+			media[a.URL] = filepath.ToSlash(filepath.Join(filepath.Base(mediaDir), name))
+		}
+	}
+	var out []byte
+	switch format {
+	case "markdown", "md":
+		out = []byte(exportMarkdown(statuses, media))
+	case "html":
+		out = []byte(exportHTML(statuses, media))
+	case "json":
+		var err error
+		if len(statuses) == 1 {
+			out, err = json.MarshalIndent(statuses[0], "", "  ")
+		} else {
+			out, err = json.MarshalIndent(statuses, "", "  ")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return os.WriteFile(p, out, 0644)
+}
+
+func exportDate(s *mastodon.Status) string {
+	return s.CreatedAt.Local().Format("2006-01-02 15:04")
+}
+
+func exportMarkdown(statuses []*mastodon.Status, media map[string]string) string {
+	var sb strings.Builder
+	for i, s := range statuses {
+		if i > 0 {
+			sb.WriteString("\n---\n\n")
+		}
+		if s.Reblog != nil {
+			sb.WriteString(fmt.Sprintf("Boosted by [%s](%s)\n\n", FormatUsername(s.Account), s.Account.URL))
+			s = s.Reblog
+		}
+		sb.WriteString(fmt.Sprintf("## [%s](%s)\n\n", FormatUsername(s.Account), s.Account.URL))
+		sb.WriteString(fmt.Sprintf("[%s](%s)", exportDate(s), s.URL))
+		if !s.EditedAt.IsZero() {
+			sb.WriteString(fmt.Sprintf(", edited %s", s.EditedAt.Local().Format("2006-01-02 15:04")))
+		}
+		sb.WriteString("\n\n")
+		if s.SpoilerText != "" {
+			sb.WriteString(fmt.Sprintf("**CW: %s**\n\n", s.SpoilerText))
+		}
+		text, urls := CleanHTML(s.Content)
+		for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+			sb.WriteString(line + "  \n")
+		}
+		sb.WriteString("\n")
+		if len(urls) > 0 {
+			for _, u := range urls {
+				sb.WriteString(fmt.Sprintf("- [%s](%s)\n", u.Text, u.URL))
+			}
+			sb.WriteString("\n")
+		}
+		if s.Poll != nil {
+			for _, o := range s.Poll.Options {
+				sb.WriteString(fmt.Sprintf("- %s (%d votes)\n", o.Title, o.VotesCount))
+			}
+			sb.WriteString("\n")
+		}
+		for _, a := range s.MediaAttachments {
+			f, ok := media[a.URL]
+			if !ok {
+				f = a.URL
+			}
+			desc := strings.ReplaceAll(a.Description, "\n", " ")
+			if a.Type == "image" {
+				sb.WriteString(fmt.Sprintf("![%s](%s)\n\n", desc, f))
+			} else {
+				sb.WriteString(fmt.Sprintf("[%s: %s](%s)\n\n", a.Type, desc, f))
+			}
+		}
+		if s.Card != nil {
+			sb.WriteString(fmt.Sprintf("[%s](%s)\n\n", s.Card.Title, s.Card.URL))
+		}
+		sb.WriteString(fmt.Sprintf("%d replies, %d boosts, %d favorites\n", s.RepliesCount, s.ReblogsCount, s.FavouritesCount))
+	}
+	return sb.String()
+}
+
+func exportHTML(statuses []*mastodon.Status, media map[string]string) string {
+	var sb strings.Builder
+	title := "Toots"
+	if len(statuses) > 0 {
+		title = fmt.Sprintf("Toot by %s", FormatUsername(StatusOrReblog(statuses[0]).Account))
+	}
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString(fmt.Sprintf("<title>%s</title>\n", html.EscapeString(title)))
+	sb.WriteString(`<style>
+body { font-family: sans-serif; max-width: 40em; margin: 2em auto; padding: 0 1em; }
+article { border-bottom: 1px solid #ccc; padding: 1em 0; }
+header img { width: 48px; height: 48px; border-radius: 4px; vertical-align: middle; margin-right: .5em; }
+.meta, .stats { color: #666; font-size: .9em; }
+.media img, .media video { max-width: 100%; }
+</style>
+</head>
+<body>
+`)
+	for _, s := range statuses {
+		sb.WriteString("<article>\n")
+		if s.Reblog != nil {
+			sb.WriteString(fmt.Sprintf("<p class=\"meta\">Boosted by <a href=\"%s\">%s</a></p>\n",
+				html.EscapeString(s.Account.URL), html.EscapeString(FormatUsername(s.Account))))
+			s = s.Reblog
+		}
+		sb.WriteString(fmt.Sprintf("<header><img src=\"%s\" alt=\"\"><a href=\"%s\">%s</a></header>\n",
+			html.EscapeString(s.Account.Avatar), html.EscapeString(s.Account.URL), html.EscapeString(FormatUsername(s.Account))))
+		sb.WriteString(fmt.Sprintf("<p class=\"meta\"><a href=\"%s\">%s</a></p>\n",
+			html.EscapeString(s.URL), exportDate(s)))
+		content := bluemonday.UGCPolicy().Sanitize(s.Content)
+		if s.SpoilerText != "" {
+			sb.WriteString(fmt.Sprintf("<details>\n<summary>%s</summary>\n%s\n</details>\n",
+				html.EscapeString(s.SpoilerText), content))
+		} else {
+			sb.WriteString(content + "\n")
+		}
+		if s.Poll != nil {
+			sb.WriteString("<ul>\n")
+			for _, o := range s.Poll.Options {
+				sb.WriteString(fmt.Sprintf("<li>%s (%d votes)</li>\n", html.EscapeString(o.Title), o.VotesCount))
+			}
+			sb.WriteString("</ul>\n")
+		}
+		if len(s.MediaAttachments) > 0 {
+			sb.WriteString("<div class=\"media\">\n")
+			for _, a := range s.MediaAttachments {
+				f, ok := media[a.URL]
+				if !ok {
+					f = a.URL
+				}
+				switch a.Type {
+				case "image":
+					sb.WriteString(fmt.Sprintf("<img src=\"%s\" alt=\"%s\">\n", html.EscapeString(f), html.EscapeString(a.Description)))
+				case "video", "gifv":
+					sb.WriteString(fmt.Sprintf("<video src=\"%s\" controls title=\"%s\"></video>\n", html.EscapeString(f), html.EscapeString(a.Description)))
+				case "audio":
+					sb.WriteString(fmt.Sprintf("<audio src=\"%s\" controls title=\"%s\"></audio>\n", html.EscapeString(f), html.EscapeString(a.Description)))
+				default:
+					sb.WriteString(fmt.Sprintf("<a href=\"%s\">%s</a>\n", html.EscapeString(f), html.EscapeString(a.Description)))
+				}
+			}
+			sb.WriteString("</div>\n")
+		}
+		if s.Card != nil {
+			sb.WriteString(fmt.Sprintf("<p><a href=\"%s\">%s</a></p>\n", html.EscapeString(s.Card.URL), html.EscapeString(s.Card.Title)))
+		}
+		sb.WriteString(fmt.Sprintf("<p class=\"stats\">%d replies, %d boosts, %d favorites</p>\n", s.RepliesCount, s.ReblogsCount, s.FavouritesCount))
+		sb.WriteString("</article>\n")
+	}
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
@@ -0,0 +1,105 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Mastodon won't schedule a status sooner than this.
+const MinScheduleAhead = 5 * time.Minute
+
+var scheduleRelative = regexp.MustCompile(`^(\d+)([wdhm])`)
+
+var scheduleFormats = []string{
+	"2006-01-02 15:04",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+// ParseSchedule parses either a relative time like +2h, +1d12h or +45m, or an
+// absolute time like 2006-01-02 15:04 or 15:04 in the local time zone. An
+// empty string returns nil, which means don't schedule.
+func ParseSchedule(s string, now time.Time) (*time.Time, error) {
+	t, err := parseTime(s, now)
+	if t == nil || err != nil {
+		return nil, err
+	}
+	if t.Sub(now) < MinScheduleAhead {
+		return nil, fmt.Errorf("the time must be at least %d minutes from now", int(MinScheduleAhead.Minutes()))
+	}
+	return t, nil
+}
+
+// ParseExpiry parses when something like a filter expires, in the same format
+// as ParseSchedule. An empty string or never returns nil, which means that it
+// never expires.
+func ParseExpiry(s string, now time.Time) (*time.Time, error) {
+	if strings.ToLower(strings.TrimSpace(s)) == "never" {
+		return nil, nil
+	}
+	t, err := parseTime(s, now)
+	if t == nil || err != nil {
+		return nil, err
+	}
+	if !t.After(now) {
+		return nil, errors.New("the time has already passed")
+	}
+	return t, nil
+}
+
+func parseTime(s string, now time.Time) (*time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	var t time.Time
+	if strings.HasPrefix(s, "+") {
+		rest := strings.ReplaceAll(s[1:], " ", "")
+		if rest == "" {
+			return nil, errors.New("missing duration after +")
+		}
+		var d time.Duration
+		for rest != "" {
+			m := scheduleRelative.FindStringSubmatch(rest)
+			if m == nil {
+				return nil, fmt.Errorf("couldn't parse %s, use e.g. +30m, +2h or +1d", s)
+			}
+			n, _ := strconv.Atoi(m[1])
+			switch m[2] {
+			case "w":
+				d += time.Duration(n) * 7 * 24 * time.Hour
+			case "d":
+				d += time.Duration(n) * 24 * time.Hour
+			case "h":
+				d += time.Duration(n) * time.Hour
+			case "m":
+				d += time.Duration(n) * time.Minute
+			}
+			rest = rest[len(m[0]):]
+		}
+		t = now.Add(d)
+	} else if c, err := time.ParseInLocation("15:04", s, now.Location()); err == nil {
+		t = time.Date(now.Year(), now.Month(), now.Day(), c.Hour(), c.Minute(), 0, 0, now.Location())
+		if t.Before(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+	} else {
+		parsed := false
+		for _, f := range scheduleFormats {
+			if c, err := time.ParseInLocation(f, s, now.Location()); err == nil {
+				t = c
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return nil, fmt.Errorf("couldn't parse %s, use e.g. +2h, 15:04 or 2006-01-02 15:04", s)
+		}
+	}
+	return &t, nil
+}